@@ -4,6 +4,8 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/pcstyle/ssh-server/internal/server"
@@ -13,7 +15,16 @@ func main() {
 	// Parse command-line flags
 	host := flag.String("host", "0.0.0.0", "Host to bind to")
 	port := flag.Int("port", 2222, "Port to listen on")
+	hostKey := flag.String("host-key", ".ssh/id_ed25519", "SSH host key, generated if missing")
 	apiURL := flag.String("api", "https://pcstyle.dev", "API base URL")
+	dataDir := flag.String("data", "data", "Directory for persistent data (drafts, inbox, scores)")
+	draftTTL := flag.Duration("draft-ttl", 24*time.Hour, "How long unsent contact drafts are kept")
+	themeDir := flag.String("themes", "themes", "Directory with user theme files (*.json)")
+	contentDir := flag.String("content", "content", "Directory with Markdown pages (*.md), secrets/ holds the hidden logbook")
+	feedURL := flag.String("feed", "https://pcstyle.dev/feed.xml", "RSS or Atom feed shown as the blog (disabled if empty)")
+	statusInterval := flag.Duration("status-interval", time.Minute, "How often project sites are checked for the status badges")
+	disable := flag.String("disable", "", "Comma-separated screens to turn off: contact, blog, projects, pages, inbox, arcade, secrets")
+	adminAddr := flag.String("admin-addr", "", "Address for the owner reply API, e.g. 127.0.0.1:8080 (disabled if empty)")
 	flag.Parse()
 
 	// Configure logger
@@ -24,7 +35,18 @@ func main() {
 	config := server.Config{
 		Host:       *host,
 		Port:       *port,
+		HostKey:    *hostKey,
 		APIBaseURL: *apiURL,
+		DataDir:    *dataDir,
+		DraftTTL:   *draftTTL,
+		AdminAddr:  *adminAddr,
+		AdminToken: os.Getenv("ADMIN_TOKEN"),
+		ThemeDir:   *themeDir,
+		ContentDir: *contentDir,
+		FeedURL:    *feedURL,
+		Disabled:   splitList(*disable),
+
+		StatusInterval: *statusInterval,
 	}
 
 	// Create and start the server
@@ -50,3 +72,14 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// splitList splits a comma-separated flag value, skipping empty entries
+func splitList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
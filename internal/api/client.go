@@ -42,19 +42,33 @@ func NewClient(baseURL string) *Client {
 	}
 }
 
-// SubmitContact sends a contact form submission to the API
-func (c *Client) SubmitContact(req ContactRequest) (*ContactResponse, error) {
+// ContactEndpoint returns the URL contact submissions are posted to
+func (c *Client) ContactEndpoint() string {
+	return c.BaseURL + "/api/contact"
+}
+
+// EncodeContact returns the exact JSON body SubmitContact will send
+func EncodeContact(req ContactRequest) ([]byte, error) {
 	// Set source to SSH
 	req.Source = "ssh"
 
-	// Marshal request to JSON
 	jsonData, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
+	return jsonData, nil
+}
+
+// SubmitContact sends a contact form submission to the API
+func (c *Client) SubmitContact(req ContactRequest) (*ContactResponse, error) {
+	// Marshal request to JSON
+	jsonData, err := EncodeContact(req)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create HTTP request
-	httpReq, err := http.NewRequest("POST", c.BaseURL+"/api/contact", bytes.NewBuffer(jsonData))
+	httpReq, err := http.NewRequest("POST", c.ContactEndpoint(), bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -84,3 +98,15 @@ func (c *Client) SubmitContact(req ContactRequest) (*ContactResponse, error) {
 
 	return &contactResp, nil
 }
+
+// ReferenceID formats n as a short reference like "SSH-3F9A21C0"
+func ReferenceID(n uint32) string {
+	return fmt.Sprintf("SSH-%08X", n)
+}
+
+// TagMessage puts ref in front of message. The API only answers with
+// success or failure, so the message itself is how the reference reaches
+// the owner.
+func TagMessage(ref, message string) string {
+	return "[" + ref + "] " + message
+}
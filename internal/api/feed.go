@@ -0,0 +1,226 @@
+package api
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// maxFeedSize caps how much of a feed is read, feeds are small
+const maxFeedSize = 5 << 20
+
+// Feed is a blog feed, whether it came in as RSS or Atom
+type Feed struct {
+	Title string `json:"title"`
+	Posts []Post `json:"posts"`
+}
+
+// Post is a single feed entry. Content is HTML.
+type Post struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Link      string    `json:"link"`
+	Published time.Time `json:"published"`
+	Content   string    `json:"content"`
+}
+
+// FetchFeed downloads and parses the RSS or Atom feed at url
+func (c *Client) FetchFeed(url string) (*Feed, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("feed error: status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feed: %w", err)
+	}
+	return ParseFeed(data)
+}
+
+// rssDoc and atomDoc only map the fields posts need
+type rssDoc struct {
+	Channel struct {
+		Title string    `xml:"title"`
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type rssItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	GUID        string `xml:"guid"`
+	PubDate     string `xml:"pubDate"`
+	Description string `xml:"description"`
+	Encoded     string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+}
+
+type atomDoc struct {
+	Title   string      `xml:"title"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID        string `xml:"id"`
+	Title     string `xml:"title"`
+	Published string `xml:"published"`
+	Updated   string `xml:"updated"`
+	Links     []struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+	} `xml:"link"`
+	Content atomText `xml:"content"`
+	Summary atomText `xml:"summary"`
+}
+
+// atomText is Atom's text construct: plain text, escaped HTML or inline XHTML
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Text  string `xml:",chardata"`
+	Inner string `xml:",innerxml"`
+}
+
+// HTML returns the text as HTML whatever type it was sent as
+func (t atomText) HTML() string {
+	switch t.Type {
+	case "xhtml":
+		return t.Inner
+	case "html":
+		return t.Text
+	default:
+		return html.EscapeString(t.Text)
+	}
+}
+
+// ParseFeed reads an RSS 2.0 or Atom document. Posts come back newest first.
+func ParseFeed(data []byte) (*Feed, error) {
+	var root struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse feed: %w", err)
+	}
+
+	feed := &Feed{}
+	switch root.XMLName.Local {
+	case "rss":
+		var doc rssDoc
+		if err := xml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse RSS feed: %w", err)
+		}
+		feed.Title = cleanText(doc.Channel.Title)
+		for _, item := range doc.Channel.Items {
+			content := item.Encoded
+			if content == "" {
+				content = item.Description
+			}
+			id := item.GUID
+			if id == "" {
+				id = item.Link
+			}
+			feed.Posts = append(feed.Posts, Post{
+				ID:        strings.TrimSpace(id),
+				Title:     cleanText(item.Title),
+				Link:      cleanText(item.Link),
+				Published: parseFeedTime(item.PubDate),
+				Content:   content,
+			})
+		}
+
+	case "feed":
+		var doc atomDoc
+		if err := xml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse Atom feed: %w", err)
+		}
+		feed.Title = cleanText(doc.Title)
+		for _, entry := range doc.Entries {
+			link := ""
+			for _, l := range entry.Links {
+				if l.Rel == "" || l.Rel == "alternate" {
+					link = l.Href
+					break
+				}
+			}
+			content := entry.Content.HTML()
+			if strings.TrimSpace(content) == "" {
+				content = entry.Summary.HTML()
+			}
+			published := entry.Published
+			if published == "" {
+				published = entry.Updated
+			}
+			feed.Posts = append(feed.Posts, Post{
+				ID:        strings.TrimSpace(entry.ID),
+				Title:     cleanText(entry.Title),
+				Link:      cleanText(link),
+				Published: parseFeedTime(published),
+				Content:   content,
+			})
+		}
+
+	default:
+		return nil, errors.New("not an RSS or Atom feed")
+	}
+
+	sort.SliceStable(feed.Posts, func(i, j int) bool {
+		return feed.Posts[i].Published.After(feed.Posts[j].Published)
+	})
+	return feed, nil
+}
+
+// cleanText trims a title or link and drops any control characters in it
+func cleanText(s string) string {
+	return strings.TrimSpace(StripControls(s))
+}
+
+// StripControls removes C0 and C1 control characters, newlines and tabs
+// aside. Feeds are remote input: an escape sequence in a post would reach
+// every visitor's terminal as is.
+func StripControls(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' || !unicode.IsControl(r) {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// feedTimeLayouts covers RSS (RFC 822 and friends) and Atom (RFC 3339)
+var feedTimeLayouts = []string{
+	time.RFC3339,
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"2006-01-02",
+}
+
+// parseFeedTime returns the zero time for dates it can't read
+func parseFeedTime(value string) time.Time {
+	value = strings.TrimSpace(value)
+	for _, layout := range feedTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,280 @@
+package arena
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// newTestHub has rooms that never tick on their own, tests call tick
+func newTestHub(seats int) *Hub {
+	return NewHub(Config{Width: 20, Height: 12, Seats: seats, Tick: time.Hour, Countdown: 2, Seed: 1})
+}
+
+// latest is the last snapshot s was sent
+func latest(t *testing.T, s *Seat) Snapshot {
+	t.Helper()
+	select {
+	case snap, ok := <-s.Updates():
+		if !ok {
+			t.Fatal("updates closed")
+		}
+		return snap
+	default:
+		t.Fatal("no update")
+		return Snapshot{}
+	}
+}
+
+func quickMatch(t *testing.T, h *Hub, name string) *Seat {
+	t.Helper()
+	s, err := h.QuickMatch(name, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestMatchmaking(t *testing.T) {
+	h := newTestHub(2)
+	a := quickMatch(t, h, "ADA")
+	b := quickMatch(t, h, "BOB")
+	if a.Room() != b.Room() || a.Player() != 0 || b.Player() != 1 {
+		t.Fatalf("ADA in room %d seat %d, BOB in room %d seat %d", a.Room(), a.Player(), b.Room(), b.Player())
+	}
+
+	// The first room is full, so quick match opens another
+	c := quickMatch(t, h, "CAT")
+	if c.Room() == a.Room() || c.Spectating() {
+		t.Fatalf("CAT in room %d, spectating %v", c.Room(), c.Spectating())
+	}
+
+	// Asking for the full room gets a spectator's seat
+	d, err := h.Join(a.Room(), "DOT", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !d.Spectating() {
+		t.Fatal("DOT got a seat in a full room")
+	}
+	d.Turn(Up) // ignored
+
+	rooms := h.Rooms()
+	want := []RoomInfo{
+		{ID: a.Room(), Phase: PhaseCountdown, Players: 2, Seats: 2, Spectators: 1},
+		{ID: c.Room(), Phase: PhaseWaiting, Players: 1, Seats: 2},
+	}
+	if len(rooms) != len(want) || rooms[0] != want[0] || rooms[1] != want[1] {
+		t.Fatalf("rooms = %+v, want %+v", rooms, want)
+	}
+
+	if _, err := h.Join(99, "EVE", nil); err != ErrNoRoom {
+		t.Fatalf("joining a missing room: %v", err)
+	}
+}
+
+func TestEverySeatSeesTheSameBoard(t *testing.T) {
+	h := newTestHub(2)
+	a := quickMatch(t, h, "ADA")
+	b := quickMatch(t, h, "BOB")
+	watcher, err := h.Join(a.Room(), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a.room.tick()
+	a.room.tick()
+	a.room.tick()
+	snaps := []Snapshot{latest(t, a), latest(t, b), latest(t, watcher)}
+	for _, snap := range snaps {
+		if snap.Phase != PhasePlaying || len(snap.Snakes) != 2 || snap.Spectators != 1 {
+			t.Fatalf("snapshot = %+v", snap)
+		}
+		// One tick into the round, a step from the spawn point
+		if head := snap.Snakes[0].Body[0]; head != (Point{4, 3}) {
+			t.Errorf("ADA's head at %v", head)
+		}
+		if snap.Snakes[1].Body[0] != snaps[0].Snakes[1].Body[0] {
+			t.Errorf("seats disagree on where BOB is")
+		}
+	}
+}
+
+func TestRound(t *testing.T) {
+	h := newTestHub(2)
+	a := quickMatch(t, h, "ADA")
+	b := quickMatch(t, h, "BOB")
+	r := a.room
+	r.tick()
+	r.tick()
+
+	// ADA heads for the top wall, three rows up, and loses
+	a.Turn(Up)
+	for range 3 {
+		r.tick()
+	}
+	snap := latest(t, b)
+	if snap.Phase != PhasePlaying || !snap.Snakes[0].Alive {
+		t.Fatalf("ADA should be on the wall's edge: %+v", snap)
+	}
+	r.tick()
+	snap = latest(t, b)
+	if snap.Phase != PhaseOver || snap.Winner != 1 || snap.Snakes[0].Alive || snap.Snakes[1].Wins != 1 {
+		t.Fatalf("BOB should have won: %+v", snap)
+	}
+	if snap.Countdown != 2*time.Hour {
+		t.Errorf("next round in %s", snap.Countdown)
+	}
+
+	r.tick()
+	r.tick()
+	snap = latest(t, a)
+	if snap.Phase != PhaseCountdown || snap.Round != 2 || !snap.Snakes[0].Alive || len(snap.Snakes[0].Body) != 3 {
+		t.Fatalf("round 2 should be counting down with both back: %+v", snap)
+	}
+}
+
+func TestCollisions(t *testing.T) {
+	h := newTestHub(3)
+	a := quickMatch(t, h, "ADA")
+	quickMatch(t, h, "BOB")
+	c := quickMatch(t, h, "CAT")
+	r := a.room
+	r.tick()
+	r.tick()
+
+	// ADA and BOB meet head on, CAT runs into ADA's tail
+	r.mu.Lock()
+	r.apples = nil
+	r.players[0].body = []Point{{5, 5}, {4, 5}, {3, 5}}
+	r.players[0].dir, r.players[0].next = Right, Right
+	r.players[1].body = []Point{{7, 5}, {8, 5}, {9, 5}}
+	r.players[1].dir, r.players[1].next = Left, Left
+	r.players[2].body = []Point{{4, 6}, {4, 7}, {4, 8}}
+	r.players[2].dir, r.players[2].next = Up, Up
+	r.mu.Unlock()
+
+	r.tick()
+	snap := latest(t, c)
+	if snap.Phase != PhaseOver || snap.Winner != -1 {
+		t.Fatalf("round should be a draw: %+v", snap)
+	}
+	for _, s := range snap.Snakes {
+		if s.Alive || len(s.Body) != 0 {
+			t.Errorf("%s survived: %+v", s.Name, s)
+		}
+	}
+}
+
+func TestLeaving(t *testing.T) {
+	h := newTestHub(2)
+	a := quickMatch(t, h, "ADA")
+	done := make(chan struct{})
+	b, err := h.QuickMatch("BOB", done)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.room.tick()
+	a.room.tick()
+
+	// BOB disconnects mid-round, ADA wins by default
+	close(done)
+	for range b.Updates() {
+	}
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case snap := <-a.Updates():
+			if snap.Phase != PhaseOver {
+				continue
+			}
+			if snap.Winner != 0 || len(snap.Snakes) != 1 {
+				t.Fatalf("ADA should have won: %+v", snap)
+			}
+		case <-timeout:
+			t.Fatal("BOB's seat was never given back")
+		}
+		break
+	}
+
+	// The last one out closes the room
+	a.Leave()
+	a.Leave()
+	if rooms := h.Rooms(); len(rooms) != 0 {
+		t.Fatalf("rooms = %+v", rooms)
+	}
+}
+
+func TestRunClosesRooms(t *testing.T) {
+	h := newTestHub(2)
+	a := quickMatch(t, h, "ADA")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		h.Run(ctx)
+		close(stopped)
+	}()
+	cancel()
+	<-stopped
+
+	for range a.Updates() {
+	}
+	a.Leave()
+	if _, err := h.QuickMatch("BOB", nil); err != ErrClosed {
+		t.Fatalf("joining after shutdown: %v", err)
+	}
+}
+
+func TestLeftSeatCannotSteer(t *testing.T) {
+	h := newTestHub(2)
+	a := quickMatch(t, h, "ADA")
+	b := quickMatch(t, h, "BOB")
+
+	// BOB leaves, CAT takes the same slot and BOB's last key arrives late
+	b.Leave()
+	c := quickMatch(t, h, "CAT")
+	if c.Player() != b.Player() {
+		t.Fatalf("CAT got seat %d, not BOB's %d", c.Player(), b.Player())
+	}
+	b.Turn(Up)
+
+	r := a.room
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if p := r.players[c.Player()]; p.next != Left {
+		t.Fatalf("CAT's snake was turned to %v", p.next)
+	}
+}
+
+func TestBoardClearsWhenAlone(t *testing.T) {
+	h := newTestHub(2)
+	a := quickMatch(t, h, "ADA")
+	b := quickMatch(t, h, "BOB")
+	r := a.room
+	r.tick()
+	r.tick()
+
+	// ADA runs into the top wall, then leaves before round 2; BOB's
+	// winning snake shouldn't linger while they wait for someone new
+	a.Turn(Up)
+	for range 4 {
+		r.tick()
+	}
+	if snap := latest(t, b); snap.Phase != PhaseOver || !snap.Snakes[1].Alive {
+		t.Fatalf("BOB should have won: %+v", snap)
+	}
+	a.Leave()
+	r.tick()
+	r.tick()
+
+	snap := latest(t, b)
+	if snap.Phase != PhaseWaiting || len(snap.Snakes) != 1 || len(snap.Apples) != 0 {
+		t.Fatalf("BOB should be waiting on an empty board: %+v", snap)
+	}
+	for _, s := range snap.Snakes {
+		if s.Alive || len(s.Body) != 0 {
+			t.Errorf("%s is still on the board: %+v", s.Name, s)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+// Package arena runs multiplayer snake. Rooms live on the server: each one
+// has its own goroutine ticking the game, and every visitor in it gets the
+// same snapshots of the board, so nobody's terminal decides what happened.
+package arena
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Defaults for a zero Config
+const (
+	DefaultWidth     = 40
+	DefaultHeight    = 16
+	DefaultSeats     = 4
+	DefaultTick      = 150 * time.Millisecond
+	DefaultCountdown = 20
+)
+
+// MaxSeats is how many players a room can have at most, one per spawn point
+const MaxSeats = 4
+
+// ErrNoRoom is returned when joining a room that has since closed
+var ErrNoRoom = errors.New("no such room")
+
+// ErrClosed is returned when joining after the hub has shut down
+var ErrClosed = errors.New("arena closed")
+
+// Config sets up every room of a hub. Zero fields get the defaults.
+type Config struct {
+	// Width and Height are the board size
+	Width  int
+	Height int
+
+	// Seats is how many players a room takes; anyone joining a full room
+	// watches instead. At most MaxSeats.
+	Seats int
+
+	// Tick is how often snakes move
+	Tick time.Duration
+
+	// Countdown is how many ticks players get to get ready before a
+	// round, and to see who won after one
+	Countdown int
+
+	// Seed makes apple placement repeatable, zero picks one from the clock
+	Seed int64
+}
+
+func (c Config) withDefaults() Config {
+	// Anything smaller doesn't fit the spawn points
+	if c.Width < 12 {
+		c.Width = DefaultWidth
+	}
+	if c.Height < 10 {
+		c.Height = DefaultHeight
+	}
+	if c.Seats <= 0 || c.Seats > MaxSeats {
+		c.Seats = DefaultSeats
+	}
+	if c.Tick <= 0 {
+		c.Tick = DefaultTick
+	}
+	if c.Countdown <= 0 {
+		c.Countdown = DefaultCountdown
+	}
+	if c.Seed == 0 {
+		c.Seed = time.Now().UnixNano()
+	}
+	return c
+}
+
+// RoomInfo is a room as the lobby lists it
+type RoomInfo struct {
+	ID         int
+	Phase      Phase
+	Players    int
+	Seats      int
+	Spectators int
+}
+
+// Full reports whether joining would only get a spectator's seat
+func (r RoomInfo) Full() bool {
+	return r.Players >= r.Seats
+}
+
+// Hub matches visitors into rooms. It's shared by every session; rooms are
+// opened as people join and closed when the last one leaves.
+type Hub struct {
+	config Config
+
+	mu     sync.Mutex
+	rooms  map[int]*room
+	nextID int
+	closed bool
+}
+
+// NewHub creates a hub with no rooms yet
+func NewHub(config Config) *Hub {
+	return &Hub{
+		config: config.withDefaults(),
+		rooms:  make(map[int]*room),
+	}
+}
+
+// Run waits until ctx is done, then closes every room. Seats in them see
+// their updates channel closed.
+func (h *Hub) Run(ctx context.Context) {
+	<-ctx.Done()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for id, r := range h.rooms {
+		r.close()
+		delete(h.rooms, id)
+	}
+}
+
+// Rooms lists the open rooms, oldest first
+func (h *Hub) Rooms() []RoomInfo {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	rooms := make([]RoomInfo, 0, len(h.rooms))
+	for _, r := range h.rooms {
+		rooms = append(rooms, r.info())
+	}
+	sort.Slice(rooms, func(i, j int) bool { return rooms[i].ID < rooms[j].ID })
+	return rooms
+}
+
+// QuickMatch seats name in the room that's closest to starting a round:
+// one with a free seat, preferring rooms that are already waiting for
+// players. With every room full, it opens a new one.
+func (h *Hub) QuickMatch(name string, done <-chan struct{}) (*Seat, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return nil, ErrClosed
+	}
+
+	var best *room
+	for _, r := range h.rooms {
+		info := r.info()
+		if info.Full() {
+			continue
+		}
+		if best == nil || betterMatch(info, best.info()) {
+			best = r
+		}
+	}
+	if best == nil {
+		best = h.open()
+	}
+	return h.seat(best, name, done), nil
+}
+
+// betterMatch prefers rooms between rounds over ones mid-round, then the
+// fuller room so games start sooner, then the older one
+func betterMatch(a, b RoomInfo) bool {
+	if playing(a) != playing(b) {
+		return !playing(a)
+	}
+	if a.Players != b.Players {
+		return a.Players > b.Players
+	}
+	return a.ID < b.ID
+}
+
+func playing(r RoomInfo) bool {
+	return r.Phase == PhasePlaying
+}
+
+// Open starts a new room with name as its first player
+func (h *Hub) Open(name string, done <-chan struct{}) (*Seat, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return nil, ErrClosed
+	}
+	return h.seat(h.open(), name, done), nil
+}
+
+// Join enters room id, as a player if it has a free seat and as a
+// spectator otherwise
+func (h *Hub) Join(id int, name string, done <-chan struct{}) (*Seat, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return nil, ErrClosed
+	}
+	r, ok := h.rooms[id]
+	if !ok {
+		return nil, ErrNoRoom
+	}
+	return h.seat(r, name, done), nil
+}
+
+// open starts a room; h.mu must be held
+func (h *Hub) open() *room {
+	h.nextID++
+	r := newRoom(h.nextID, h.config)
+	h.rooms[r.id] = r
+	go r.run()
+	return r
+}
+
+// seat adds a member to r and gives the seat back once done is closed,
+// for visitors who disconnect without leaving; h.mu must be held
+func (h *Hub) seat(r *room, name string, done <-chan struct{}) *Seat {
+	s := r.join(name)
+	s.hub = h
+	if done != nil {
+		go func() {
+			select {
+			case <-done:
+				s.Leave()
+			case <-s.left:
+			}
+		}()
+	}
+	return s
+}
+
+// leave takes s out of its room, closing the room if it was the last one in
+func (h *Hub) leave(s *Seat) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if s.room.leave(s) == 0 && h.rooms[s.room.id] == s.room {
+		s.room.close()
+		delete(h.rooms, s.room.id)
+	}
+}
+
+// Seat is one visitor's place in a room: a player's snake, or a spectator
+// (Player is -1). Updates delivers every new state of the room.
+type Seat struct {
+	hub     *Hub
+	room    *room
+	player  int
+	updates chan Snapshot
+	left    chan struct{}
+	once    sync.Once
+}
+
+// Room is the ID of the room the seat is in
+func (s *Seat) Room() int {
+	return s.room.id
+}
+
+// Player is the seat's snake, -1 for spectators
+func (s *Seat) Player() int {
+	return s.player
+}
+
+// Spectating reports whether the seat only watches
+func (s *Seat) Spectating() bool {
+	return s.player < 0
+}
+
+// Updates delivers snapshots of the room. A slow reader only misses the
+// ones in between, the latest is always there. It's closed when the seat
+// leaves or the room shuts down.
+func (s *Seat) Updates() <-chan Snapshot {
+	return s.updates
+}
+
+// Turn steers the seat's snake from the next tick on. Spectators can't.
+func (s *Seat) Turn(d Dir) {
+	if s.Spectating() {
+		return
+	}
+	s.room.turn(s, d)
+}
+
+// Leave gives the seat up. It's safe to call more than once.
+func (s *Seat) Leave() {
+	s.once.Do(func() {
+		close(s.left)
+		s.hub.leave(s)
+	})
+}
@@ -0,0 +1,484 @@
+package arena
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// applePoints is what an apple is worth
+const applePoints = 10
+
+// Phase is where a room is in its round
+type Phase int
+
+const (
+	PhaseWaiting   Phase = iota // fewer than two players, nothing moves
+	PhaseCountdown              // snakes are on their marks
+	PhasePlaying
+	PhaseOver // the round's winner is shown until the next countdown
+)
+
+// Dir is the way a snake is heading
+type Dir struct{ X, Y int }
+
+var (
+	Up    = Dir{0, -1}
+	Down  = Dir{0, 1}
+	Left  = Dir{-1, 0}
+	Right = Dir{1, 0}
+)
+
+// Point is a cell on the board, 0,0 at the top left
+type Point struct{ X, Y int }
+
+// Snake is one player in a snapshot
+type Snake struct {
+	Player int
+	Name   string
+	// Body is head first, empty when the snake died or its player joined
+	// mid-round
+	Body  []Point
+	Alive bool
+	// Score is this round's apples, Wins the rounds won in this room
+	Score int
+	Wins  int
+}
+
+// Snapshot is the state of a room after a tick, what every seat draws.
+// It's shared by all of them, so it must not be modified.
+type Snapshot struct {
+	Room   int
+	Phase  Phase
+	Round  int
+	Width  int
+	Height int
+	Seats  int
+
+	// Countdown is how long until the next round starts, during
+	// PhaseCountdown and PhaseOver
+	Countdown time.Duration
+
+	// Snakes are the seated players, by seat
+	Snakes     []Snake
+	Apples     []Point
+	Spectators int
+
+	// Winner is the player who won the last round, -1 for a draw
+	Winner int
+}
+
+// player is a seat's snake as the room keeps it
+type player struct {
+	name      string
+	body      []Point
+	dir, next Dir
+	alive     bool
+	score     int
+	wins      int
+}
+
+// room is one board. Its goroutine ticks the game; joins, leaves and turns
+// come in from sessions under mu.
+type room struct {
+	id     int
+	config Config
+	random *rand.Rand
+	stop   chan struct{}
+
+	mu        sync.Mutex
+	closed    bool
+	phase     Phase
+	round     int
+	countdown int // ticks left in PhaseCountdown and PhaseOver
+	winner    int
+	players   [MaxSeats]*player
+	apples    []Point
+	members   map[*Seat]bool
+}
+
+func newRoom(id int, config Config) *room {
+	return &room{
+		id:      id,
+		config:  config,
+		random:  rand.New(rand.NewSource(config.Seed + int64(id))),
+		stop:    make(chan struct{}),
+		winner:  -1,
+		members: make(map[*Seat]bool),
+	}
+}
+
+// run ticks the room until it's closed
+func (r *room) run() {
+	ticker := time.NewTicker(r.config.Tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			r.tick()
+		}
+	}
+}
+
+// close stops the room and closes every member's updates
+func (r *room) close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+	r.closed = true
+	close(r.stop)
+	for s := range r.members {
+		close(s.updates)
+		delete(r.members, s)
+	}
+}
+
+// info is the room for the lobby
+func (r *room) info() RoomInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	players := r.seated()
+	return RoomInfo{
+		ID:         r.id,
+		Phase:      r.phase,
+		Players:    players,
+		Seats:      r.config.Seats,
+		Spectators: len(r.members) - players,
+	}
+}
+
+// join adds a member, seated if there's room. Joining mid-countdown still
+// gets a snake this round; mid-round waits for the next one.
+func (r *room) join(name string) *Seat {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := &Seat{
+		room:    r,
+		player:  -1,
+		updates: make(chan Snapshot, 1),
+		left:    make(chan struct{}),
+	}
+	for i := range r.config.Seats {
+		if r.players[i] == nil {
+			s.player = i
+			r.players[i] = &player{name: name}
+			break
+		}
+	}
+	r.members[s] = true
+
+	switch {
+	case s.Spectating():
+	case r.phase == PhaseWaiting && r.seated() >= 2:
+		r.startCountdown()
+	case r.phase == PhaseCountdown:
+		r.place(s.player)
+		r.spawnApple()
+	}
+	r.broadcast()
+	return s
+}
+
+// leave removes a member and reports how many are left
+func (r *room) leave(s *Seat) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.members[s] {
+		return len(r.members)
+	}
+	delete(r.members, s)
+	close(s.updates)
+
+	if !s.Spectating() {
+		r.players[s.player] = nil
+		switch r.phase {
+		case PhaseCountdown:
+			if r.seated() < 2 {
+				r.wait()
+			}
+		case PhasePlaying:
+			r.settle()
+		}
+	}
+	r.broadcast()
+	return len(r.members)
+}
+
+// turn queues a direction for s's snake for the next tick; turning back
+// into the snake's own neck is ignored. A seat that has left steers
+// nothing, even if someone else has its slot by now.
+func (r *room) turn(s *Seat, d Dir) {
+	if d != Up && d != Down && d != Left && d != Right {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.members[s] {
+		return
+	}
+	if p := r.players[s.player]; p != nil && (d.X != -p.dir.X || d.Y != -p.dir.Y) {
+		p.next = d
+	}
+}
+
+// tick moves the room along one step and sends everyone the result
+func (r *room) tick() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch r.phase {
+	case PhaseWaiting:
+		return // nothing changes, nothing to send
+	case PhaseCountdown:
+		if r.countdown--; r.countdown <= 0 {
+			r.phase = PhasePlaying
+		}
+	case PhasePlaying:
+		r.step()
+		r.settle()
+	case PhaseOver:
+		if r.countdown--; r.countdown <= 0 {
+			if r.seated() >= 2 {
+				r.startCountdown()
+			} else {
+				r.wait()
+			}
+		}
+	}
+	r.broadcast()
+}
+
+// wait clears the board until there are players enough for a round
+func (r *room) wait() {
+	r.phase = PhaseWaiting
+	r.apples = nil
+	for _, p := range r.players {
+		if p != nil {
+			p.body = nil
+			p.alive = false
+		}
+	}
+}
+
+// startCountdown sets up the next round: every seated player on their
+// spawn point, an apple each and one to fight over
+func (r *room) startCountdown() {
+	r.round++
+	r.phase = PhaseCountdown
+	r.countdown = r.config.Countdown
+	r.winner = -1
+	r.apples = nil
+	for i, p := range r.players {
+		if p != nil {
+			r.place(i)
+		}
+	}
+	r.refill()
+}
+
+// place puts player i on its spawn point, facing into the board
+func (r *room) place(i int) {
+	w, h := r.config.Width, r.config.Height
+	var head Point
+	var dir Dir
+	switch i {
+	case 0:
+		head, dir = Point{3, h / 4}, Right
+	case 1:
+		head, dir = Point{w - 4, h - 1 - h/4}, Left
+	case 2:
+		head, dir = Point{w / 4, h - 4}, Up
+	default:
+		head, dir = Point{w - 1 - w/4, 3}, Down
+	}
+
+	p := r.players[i]
+	p.body = []Point{head, {head.X - dir.X, head.Y - dir.Y}, {head.X - 2*dir.X, head.Y - 2*dir.Y}}
+	p.dir, p.next = dir, dir
+	p.alive = true
+	p.score = 0
+}
+
+// step moves every snake at once, then works out who ran into what:
+// walls, any body (their own included) and each other's heads. Losers
+// vanish from the board.
+func (r *room) step() {
+	eaten := false
+	for _, p := range r.players {
+		if p == nil || !p.alive {
+			continue
+		}
+		p.dir = p.next
+		head := Point{p.body[0].X + p.dir.X, p.body[0].Y + p.dir.Y}
+		p.body = append([]Point{head}, p.body...)
+		if i := r.appleAt(head); i >= 0 {
+			r.apples = append(r.apples[:i], r.apples[i+1:]...)
+			p.score += applePoints
+			eaten = true
+		} else {
+			p.body = p.body[:len(p.body)-1]
+		}
+	}
+
+	// A head on a cell anything else is on crashed into it
+	occupied := make(map[Point]int)
+	for _, p := range r.players {
+		if p != nil && p.alive {
+			for _, c := range p.body {
+				occupied[c]++
+			}
+		}
+	}
+	var crashed []*player
+	for _, p := range r.players {
+		if p == nil || !p.alive {
+			continue
+		}
+		head := p.body[0]
+		if head.X < 0 || head.X >= r.config.Width || head.Y < 0 || head.Y >= r.config.Height || occupied[head] > 1 {
+			crashed = append(crashed, p)
+		}
+	}
+	for _, p := range crashed {
+		p.alive = false
+		p.body = nil
+	}
+
+	if eaten {
+		r.refill()
+	}
+}
+
+// settle ends the round once at most one snake is left
+func (r *room) settle() {
+	var alive []*player
+	for _, p := range r.players {
+		if p != nil && p.alive {
+			alive = append(alive, p)
+		}
+	}
+	if len(alive) > 1 {
+		return
+	}
+
+	r.winner = -1
+	if len(alive) == 1 {
+		alive[0].wins++
+		for i, p := range r.players {
+			if p == alive[0] {
+				r.winner = i
+			}
+		}
+	}
+	r.phase = PhaseOver
+	r.countdown = r.config.Countdown
+}
+
+// seated counts the players, spectators aside
+func (r *room) seated() int {
+	n := 0
+	for _, p := range r.players {
+		if p != nil {
+			n++
+		}
+	}
+	return n
+}
+
+func (r *room) appleAt(c Point) int {
+	for i, a := range r.apples {
+		if a == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// refill tops the board up to an apple per player plus one
+func (r *room) refill() {
+	for len(r.apples) < r.seated()+1 && r.spawnApple() {
+	}
+}
+
+// spawnApple puts an apple on a free cell, reporting false when it
+// couldn't find one
+func (r *room) spawnApple() bool {
+	for range 1000 {
+		c := Point{r.random.Intn(r.config.Width), r.random.Intn(r.config.Height)}
+		if r.free(c) {
+			r.apples = append(r.apples, c)
+			return true
+		}
+	}
+	return false
+}
+
+func (r *room) free(c Point) bool {
+	if r.appleAt(c) >= 0 {
+		return false
+	}
+	for _, p := range r.players {
+		if p == nil {
+			continue
+		}
+		for _, b := range p.body {
+			if b == c {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// broadcast hands everyone the current state. Updates channels hold one
+// snapshot; a member who hasn't read the last one yet gets it replaced.
+// Only the room sends, under mu, so the second send never blocks.
+func (r *room) broadcast() {
+	snap := r.snapshot()
+	for s := range r.members {
+		select {
+		case s.updates <- snap:
+		default:
+			select {
+			case <-s.updates:
+			default:
+			}
+			s.updates <- snap
+		}
+	}
+}
+
+func (r *room) snapshot() Snapshot {
+	snap := Snapshot{
+		Room:       r.id,
+		Phase:      r.phase,
+		Round:      r.round,
+		Width:      r.config.Width,
+		Height:     r.config.Height,
+		Seats:      r.config.Seats,
+		Countdown:  time.Duration(r.countdown) * r.config.Tick,
+		Apples:     append([]Point(nil), r.apples...),
+		Spectators: len(r.members) - r.seated(),
+		Winner:     r.winner,
+	}
+	for i, p := range r.players {
+		if p == nil {
+			continue
+		}
+		snap.Snakes = append(snap.Snakes, Snake{
+			Player: i,
+			Name:   p.name,
+			Body:   append([]Point(nil), p.body...),
+			Alive:  p.alive,
+			Score:  p.score,
+			Wins:   p.wins,
+		})
+	}
+	return snap
+}
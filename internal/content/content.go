@@ -0,0 +1,221 @@
+package content
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Page is a Markdown file with its front matter parsed out
+type Page struct {
+	// Slug is the file name without extension, e.g. "about"
+	Slug string
+
+	// Locale is the language suffix ("about.pl.md"), empty for the default
+	Locale string
+
+	Title       string
+	Menu        string // Menu label, defaults to Title
+	Description string // Shown next to the menu entry
+	Order       int    // Menu position, lower comes first
+	Hidden      bool   // Left out of the menu unless unlocked
+	Unlock      string // Typing this on the home screen reveals a hidden page
+
+	// Body is the Markdown after the front matter
+	Body string
+}
+
+// MenuTitle is the label used in menus
+func (p Page) MenuTitle() string {
+	if p.Menu != "" {
+		return p.Menu
+	}
+	return p.Title
+}
+
+// Library holds every page from a content directory. A nil Library is
+// empty, so views work without any content at all.
+type Library struct {
+	// slug -> locale -> page
+	pages map[string]map[string]Page
+}
+
+// Load reads *.md files from dir. Subdirectories are ignored (they're
+// separate libraries) and a missing directory is just an empty library.
+func Load(dir string) (*Library, error) {
+	lib := &Library{pages: make(map[string]map[string]Page)}
+	if dir == "" {
+		return lib, nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.md"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+			return lib, nil
+		}
+	}
+
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		slug, locale := splitName(filepath.Base(path))
+		page, err := Parse(slug, locale, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+
+		if lib.pages[slug] == nil {
+			lib.pages[slug] = make(map[string]Page)
+		}
+		lib.pages[slug][locale] = page
+	}
+
+	return lib, nil
+}
+
+// splitName turns "about.pl.md" into ("about", "pl")
+func splitName(name string) (slug, locale string) {
+	name = strings.TrimSuffix(name, ".md")
+	if i := strings.LastIndexByte(name, '.'); i > 0 {
+		return name[:i], name[i+1:]
+	}
+	return name, ""
+}
+
+// Parse splits front matter from the Markdown body. Front matter is a block
+// of "key: value" lines between two "---" lines at the top of the file.
+func Parse(slug, locale string, data []byte) (Page, error) {
+	page := Page{Slug: slug, Locale: locale, Title: slug}
+
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if !strings.HasPrefix(text, "---\n") {
+		page.Body = text
+		return page, nil
+	}
+
+	header, body, ok := strings.Cut(text[len("---\n"):], "\n---\n")
+	if !ok {
+		// Front matter with nothing after it
+		header, ok = strings.CutSuffix(text[len("---\n"):], "\n---")
+		if !ok {
+			return page, errors.New("front matter is not closed with ---")
+		}
+	}
+	page.Body = strings.TrimLeft(body, "\n")
+
+	scanner := bufio.NewScanner(bytes.NewBufferString(header))
+	for line := 1; scanner.Scan(); line++ {
+		raw := strings.TrimSpace(scanner.Text())
+		if raw == "" || strings.HasPrefix(raw, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(raw, ":")
+		if !ok {
+			return page, fmt.Errorf("front matter line %d: expected key: value", line)
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = unquote(stripComment(strings.TrimSpace(value)))
+
+		switch key {
+		case "title":
+			page.Title = value
+		case "menu":
+			page.Menu = value
+		case "description":
+			page.Description = value
+		case "order":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return page, fmt.Errorf("front matter line %d: order must be a number", line)
+			}
+			page.Order = n
+		case "hidden":
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return page, fmt.Errorf("front matter line %d: hidden must be true or false", line)
+			}
+			page.Hidden = b
+		case "unlock":
+			page.Unlock = strings.ToLower(value)
+		default:
+			// Unknown keys are allowed so pages can carry extra notes
+		}
+	}
+
+	return page, nil
+}
+
+// stripComment drops a trailing " # comment" from unquoted values, like YAML
+func stripComment(value string) string {
+	if strings.HasPrefix(value, `"`) || strings.HasPrefix(value, "'") {
+		return value
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		return strings.TrimSpace(value[:i])
+	}
+	return value
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// Page returns slug in locale, falling back to the default file
+func (l *Library) Page(slug, locale string) (Page, bool) {
+	if l == nil {
+		return Page{}, false
+	}
+	variants, ok := l.pages[slug]
+	if !ok {
+		return Page{}, false
+	}
+	if page, ok := variants[locale]; ok {
+		return page, true
+	}
+	if page, ok := variants[""]; ok {
+		return page, true
+	}
+	// Only translated files exist, any of them beats nothing
+	for _, page := range variants {
+		return page, true
+	}
+	return Page{}, false
+}
+
+// List returns one page per slug in locale, sorted by order then slug
+func (l *Library) List(locale string) []Page {
+	if l == nil {
+		return nil
+	}
+
+	pages := make([]Page, 0, len(l.pages))
+	for slug := range l.pages {
+		if page, ok := l.Page(slug, locale); ok {
+			pages = append(pages, page)
+		}
+	}
+	sort.Slice(pages, func(i, j int) bool {
+		if pages[i].Order != pages[j].Order {
+			return pages[i].Order < pages[j].Order
+		}
+		return pages[i].Slug < pages[j].Slug
+	})
+	return pages
+}
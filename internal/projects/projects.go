@@ -0,0 +1,118 @@
+package projects
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	// Screenshots can be any of these
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// Project is one entry of the projects data file
+type Project struct {
+	Slug        string   `json:"slug"`
+	Name        string   `json:"name"`
+	URL         string   `json:"url"` // Checked for the up/down badge
+	Tagline     string   `json:"tagline"`
+	Description string   `json:"description"` // Markdown
+	Stack       []string `json:"stack"`
+	Links       []Link   `json:"links"`
+
+	// Screenshots are image paths, relative to the data file
+	Screenshots []string `json:"screenshots"`
+
+	// Translations override the tagline and description per locale
+	Translations map[string]Translation `json:"translations"`
+}
+
+// Link is a labelled URL shown on a project's detail page
+type Link struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+// Translation holds the localized text of a project
+type Translation struct {
+	Tagline     string `json:"tagline"`
+	Description string `json:"description"`
+}
+
+// Localized returns the project with its text in locale, where translated
+func (p Project) Localized(locale string) Project {
+	t, ok := p.Translations[locale]
+	if !ok {
+		return p
+	}
+	if t.Tagline != "" {
+		p.Tagline = t.Tagline
+	}
+	if t.Description != "" {
+		p.Description = t.Description
+	}
+	return p
+}
+
+// file is the layout of the data file
+type file struct {
+	Projects []Project `json:"projects"`
+}
+
+// Load reads the projects data file. A missing file means no projects.
+func Load(path string) ([]Project, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	for i := range f.Projects {
+		p := &f.Projects[i]
+		if p.Name == "" {
+			return nil, fmt.Errorf("%s: project %d has no name", path, i+1)
+		}
+		if p.Slug == "" {
+			p.Slug = p.Name
+		}
+		for j, shot := range p.Screenshots {
+			if !filepath.IsAbs(shot) {
+				p.Screenshots[j] = filepath.Join(dir, shot)
+			}
+		}
+	}
+
+	return f.Projects, nil
+}
+
+// LoadImage decodes a screenshot
+func LoadImage(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", filepath.Base(path), err)
+	}
+	return img, nil
+}
@@ -0,0 +1,146 @@
+package projects
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// DefaultCheckInterval is how often project URLs are checked
+const DefaultCheckInterval = time.Minute
+
+// State is the result of the last check of a URL
+type State int
+
+const (
+	StateUnknown State = iota // not checked yet
+	StateUp
+	StateDown
+)
+
+// Status is what the monitor knows about one URL
+type Status struct {
+	State     State
+	Code      int // HTTP status, 0 when the request failed
+	Latency   time.Duration
+	CheckedAt time.Time
+	Err       string
+}
+
+// Monitor checks project URLs in the background. Results are kept in memory
+// and shared by every session, so visitors never wait on a check and the
+// sites aren't hit once per connection.
+type Monitor struct {
+	client   *http.Client
+	urls     []string
+	interval time.Duration
+
+	mu       sync.RWMutex
+	statuses map[string]Status
+}
+
+// NewMonitor creates a monitor for the URLs of projects. A zero interval
+// means DefaultCheckInterval.
+func NewMonitor(projects []Project, interval time.Duration) *Monitor {
+	if interval <= 0 {
+		interval = DefaultCheckInterval
+	}
+
+	seen := make(map[string]bool)
+	var urls []string
+	for _, p := range projects {
+		if p.URL != "" && !seen[p.URL] {
+			seen[p.URL] = true
+			urls = append(urls, p.URL)
+		}
+	}
+
+	return &Monitor{
+		client:   &http.Client{Timeout: 10 * time.Second},
+		urls:     urls,
+		interval: interval,
+		statuses: make(map[string]Status),
+	}
+}
+
+// Run checks every URL right away and then once per interval, until ctx is done
+func (m *Monitor) Run(ctx context.Context) {
+	if m == nil || len(m.urls) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		m.checkAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Monitor) checkAll(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, url := range m.urls {
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+			status := m.check(ctx, url)
+
+			m.mu.Lock()
+			m.statuses[url] = status
+			m.mu.Unlock()
+		}(url)
+	}
+	wg.Wait()
+}
+
+// check requests url once. Anything below 500 counts as up: the server
+// answered, even if it didn't like the request.
+func (m *Monitor) check(ctx context.Context, url string) Status {
+	status := Status{CheckedAt: time.Now()}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		status.State, status.Err = StateDown, err.Error()
+		return status
+	}
+	req.Header.Set("User-Agent", "pcstyle-ssh-status/1.0")
+
+	start := time.Now()
+	resp, err := m.client.Do(req)
+	if err != nil {
+		status.State, status.Err = StateDown, err.Error()
+		log.Debug("Project check failed", "url", url, "error", err)
+		return status
+	}
+	status.Latency = time.Since(start)
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+
+	status.Code = resp.StatusCode
+	if resp.StatusCode >= 500 {
+		status.State, status.Err = StateDown, fmt.Sprintf("status %d", resp.StatusCode)
+	} else {
+		status.State = StateUp
+	}
+	return status
+}
+
+// Status returns the last known status of url
+func (m *Monitor) Status(url string) Status {
+	if m == nil {
+		return Status{}
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.statuses[url]
+}
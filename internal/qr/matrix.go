@@ -0,0 +1,274 @@
+package qr
+
+// matrix is a symbol being drawn. function marks modules that belong to
+// finder, timing, alignment, format and version patterns, which data and
+// masks leave alone.
+type matrix struct {
+	version  int
+	size     int
+	modules  [][]bool
+	function [][]bool
+}
+
+func newCode(version int) *matrix {
+	size := version*4 + 17
+	m := &matrix{version: version, size: size}
+	m.modules = make([][]bool, size)
+	m.function = make([][]bool, size)
+	for y := range m.modules {
+		m.modules[y] = make([]bool, size)
+		m.function[y] = make([]bool, size)
+	}
+	return m
+}
+
+func (m *matrix) set(x, y int, dark bool) {
+	m.modules[y][x] = dark
+	m.function[y][x] = true
+}
+
+func (m *matrix) drawFunctionPatterns(level Level) {
+	// Timing lines first, finders and alignment patterns cover their ends
+	for i := 0; i < m.size; i++ {
+		m.set(6, i, i%2 == 0)
+		m.set(i, 6, i%2 == 0)
+	}
+
+	m.drawFinder(3, 3)
+	m.drawFinder(m.size-4, 3)
+	m.drawFinder(3, m.size-4)
+
+	pos := alignments[m.version-1]
+	for i, x := range pos {
+		for j, y := range pos {
+			// The three corners are taken by finders
+			if (i == 0 && j == 0) || (i == 0 && j == len(pos)-1) || (i == len(pos)-1 && j == 0) {
+				continue
+			}
+			m.drawAlignment(x, y)
+		}
+	}
+
+	// Reserve the format areas, the real bits go in once a mask is picked
+	m.drawFormat(level, 0)
+	m.drawVersion()
+}
+
+// drawFinder draws a finder pattern with its separator around center x, y
+func (m *matrix) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || yy < 0 || xx >= m.size || yy >= m.size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			m.set(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (m *matrix) drawAlignment(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			m.set(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// drawFormat writes both copies of the level and mask, BCH protected
+func (m *matrix) drawFormat(level Level, mask int) {
+	// Level bits as the spec numbers them: L=01, M=00
+	levelBits := 0
+	if level == L {
+		levelBits = 1
+	}
+	data := levelBits<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(i int) bool { return bits>>i&1 == 1 }
+
+	// Around the top left finder
+	for i := 0; i <= 5; i++ {
+		m.set(8, i, bit(i))
+	}
+	m.set(8, 7, bit(6))
+	m.set(8, 8, bit(7))
+	m.set(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		m.set(14-i, 8, bit(i))
+	}
+
+	// Split between the other two finders
+	for i := 0; i < 8; i++ {
+		m.set(m.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		m.set(8, m.size-15+i, bit(i))
+	}
+	m.set(8, m.size-8, true) // always dark
+}
+
+// drawVersion writes the version blocks, only present from version 7
+func (m *matrix) drawVersion() {
+	if m.version < 7 {
+		return
+	}
+	rem := m.version
+	for i := 0; i < 12; i++ {
+		rem = rem<<1 ^ (rem>>11)*0x1F25
+	}
+	bits := m.version<<12 | rem
+	for i := 0; i < 18; i++ {
+		dark := bits>>i&1 == 1
+		a, b := m.size-11+i%3, i/3
+		m.set(a, b, dark)
+		m.set(b, a, dark)
+	}
+}
+
+// drawCodewords fills data in the zigzag order: two columns at a time from
+// the right, alternating up and down, skipping the vertical timing line
+func (m *matrix) drawCodewords(data []byte) {
+	i := 0
+	for right := m.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < m.size; vert++ {
+			y := vert
+			if upward {
+				y = m.size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if m.function[y][x] || i >= len(data)*8 {
+					continue
+				}
+				m.modules[y][x] = data[i/8]>>(7-i%8)&1 == 1
+				i++
+			}
+		}
+	}
+}
+
+// applyMask flips data modules where the mask pattern says so
+func (m *matrix) applyMask(mask int) {
+	for y := 0; y < m.size; y++ {
+		for x := 0; x < m.size; x++ {
+			if m.function[y][x] {
+				continue
+			}
+			var flip bool
+			switch mask {
+			case 0:
+				flip = (x+y)%2 == 0
+			case 1:
+				flip = y%2 == 0
+			case 2:
+				flip = x%3 == 0
+			case 3:
+				flip = (x+y)%3 == 0
+			case 4:
+				flip = (x/3+y/2)%2 == 0
+			case 5:
+				flip = x*y%2+x*y%3 == 0
+			case 6:
+				flip = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				flip = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if flip {
+				m.modules[y][x] = !m.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores how hard the symbol is to scan, lower is better
+func (m *matrix) penalty() int {
+	score := 0
+	dark := 0
+
+	// Runs of five or more modules of one color, in rows and columns
+	for y := 0; y < m.size; y++ {
+		for _, vertical := range []bool{false, true} {
+			run := 0
+			var prev bool
+			for x := 0; x < m.size; x++ {
+				cell := m.modules[y][x]
+				if vertical {
+					cell = m.modules[x][y]
+				}
+				if x > 0 && cell == prev {
+					run++
+				} else {
+					run = 1
+				}
+				prev = cell
+				if run == 5 {
+					score += 3
+				} else if run > 5 {
+					score++
+				}
+			}
+		}
+	}
+
+	// 2x2 blocks of one color
+	for y := 0; y < m.size-1; y++ {
+		for x := 0; x < m.size-1; x++ {
+			c := m.modules[y][x]
+			if c == m.modules[y][x+1] && c == m.modules[y+1][x] && c == m.modules[y+1][x+1] {
+				score += 3
+			}
+		}
+	}
+
+	// Patterns that look like finders
+	finderLike := [][]bool{
+		{true, false, true, true, true, false, true, false, false, false, false},
+		{false, false, false, false, true, false, true, true, true, false, true},
+	}
+	for y := 0; y < m.size; y++ {
+		for x := 0; x+11 <= m.size; x++ {
+			for _, pattern := range finderLike {
+				row, col := true, true
+				for k, want := range pattern {
+					row = row && m.modules[y][x+k] == want
+					col = col && m.modules[x+k][y] == want
+				}
+				if row {
+					score += 40
+				}
+				if col {
+					score += 40
+				}
+			}
+		}
+	}
+
+	// Balance of dark and light
+	for y := 0; y < m.size; y++ {
+		for x := 0; x < m.size; x++ {
+			if m.modules[y][x] {
+				dark++
+			}
+		}
+	}
+	percent := dark * 100 / (m.size * m.size)
+	score += abs(percent-50) / 5 * 10
+
+	return score
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,188 @@
+// Package qr encodes short texts (URLs, vCards) as QR codes. It only does
+// what the SSH UI needs: byte mode, error correction levels L and M, and
+// versions 1 to 15, which fits a little over 500 bytes.
+package qr
+
+import (
+	"errors"
+)
+
+// Level is the error correction level
+type Level int
+
+const (
+	// M recovers about 15% of the code, the usual choice
+	M Level = iota
+	// L recovers about 7%, for texts too long for M
+	L
+)
+
+// ErrTooLong is returned for data that doesn't fit the largest version
+var ErrTooLong = errors.New("qr: data too long")
+
+// Code is an encoded QR symbol. Dark reports module colors; the quiet zone
+// around the symbol is up to the caller.
+type Code struct {
+	Size    int
+	Version int
+	modules [][]bool
+}
+
+// Dark reports whether the module at row y, column x is dark
+func (c *Code) Dark(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+	return c.modules[y][x]
+}
+
+// block layout of one version at one level: EC codewords per block, then
+// the two groups of blocks and their data codewords
+type layout struct {
+	ec         int
+	g1, g1Data int
+	g2, g2Data int
+}
+
+// layouts[level][version-1], from the ISO 18004 tables
+var layouts = [2][15]layout{
+	M: {
+		{10, 1, 16, 0, 0}, {16, 1, 28, 0, 0}, {26, 1, 44, 0, 0}, {18, 2, 32, 0, 0}, {24, 2, 43, 0, 0},
+		{16, 4, 27, 0, 0}, {18, 4, 31, 0, 0}, {22, 2, 38, 2, 39}, {22, 3, 36, 2, 37}, {26, 4, 43, 1, 44},
+		{30, 1, 50, 4, 51}, {22, 6, 36, 2, 37}, {22, 8, 37, 1, 38}, {24, 4, 40, 5, 41}, {24, 5, 41, 5, 42},
+	},
+	L: {
+		{7, 1, 19, 0, 0}, {10, 1, 34, 0, 0}, {15, 1, 55, 0, 0}, {20, 1, 80, 0, 0}, {26, 1, 108, 0, 0},
+		{18, 2, 68, 0, 0}, {20, 2, 78, 0, 0}, {24, 2, 97, 0, 0}, {30, 2, 116, 0, 0}, {18, 2, 68, 2, 69},
+		{20, 4, 81, 0, 0}, {24, 2, 92, 2, 93}, {26, 4, 107, 0, 0}, {30, 3, 115, 1, 116}, {22, 5, 87, 1, 88},
+	},
+}
+
+// alignment pattern centers per version
+var alignments = [15][]int{
+	nil, {6, 18}, {6, 22}, {6, 26}, {6, 30}, {6, 34},
+	{6, 22, 38}, {6, 24, 42}, {6, 26, 46}, {6, 28, 50}, {6, 30, 54}, {6, 32, 58}, {6, 34, 62},
+	{6, 26, 46, 66}, {6, 26, 48, 70},
+}
+
+const maxVersion = 15
+
+func (l layout) dataCodewords() int {
+	return l.g1*l.g1Data + l.g2*l.g2Data
+}
+
+// Encode picks the smallest version that holds data at level M, dropping
+// to L when M can't fit it at all
+func Encode(data []byte) (*Code, error) {
+	for _, level := range []Level{M, L} {
+		for version := 1; version <= maxVersion; version++ {
+			if capacity(version, level) >= len(data) {
+				return encode(data, version, level), nil
+			}
+		}
+	}
+	return nil, ErrTooLong
+}
+
+// capacity is how many bytes fit a version in byte mode
+func capacity(version int, level Level) int {
+	bits := layouts[level][version-1].dataCodewords()*8 - 4 - countBits(version)
+	return bits / 8
+}
+
+func countBits(version int) int {
+	if version < 10 {
+		return 8
+	}
+	return 16
+}
+
+func encode(data []byte, version int, level Level) *Code {
+	lay := layouts[level][version-1]
+
+	// Mode, length, data, terminator, then padding to fill the capacity
+	var bits bitBuffer
+	bits.append(0b0100, 4)
+	bits.append(len(data), countBits(version))
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+	capacityBits := lay.dataCodewords() * 8
+	bits.append(0, min(4, capacityBits-len(bits)))
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < capacityBits; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	codewords := interleave(bits.bytes(), lay)
+
+	c := newCode(version)
+	c.drawFunctionPatterns(level)
+	c.drawCodewords(codewords)
+
+	// Keep the mask the spec's penalty rules like best
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormat(level, mask)
+		if p := c.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		c.applyMask(mask) // masks are XOR, applying again undoes it
+	}
+	c.applyMask(best)
+	c.drawFormat(level, best)
+
+	return &Code{Size: c.size, Version: version, modules: c.modules}
+}
+
+// interleave splits data into blocks, adds error correction to each and
+// interleaves everything the way the spec lays it out
+func interleave(data []byte, lay layout) []byte {
+	var blocks, ecBlocks [][]byte
+	divisor := rsDivisor(lay.ec)
+	offset := 0
+	for i := 0; i < lay.g1+lay.g2; i++ {
+		n := lay.g1Data
+		if i >= lay.g1 {
+			n = lay.g2Data
+		}
+		block := data[offset : offset+n]
+		offset += n
+		blocks = append(blocks, block)
+		ecBlocks = append(ecBlocks, rsRemainder(block, divisor))
+	}
+
+	var out []byte
+	for i := 0; i < max(lay.g1Data, lay.g2Data); i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				out = append(out, block[i])
+			}
+		}
+	}
+	for i := 0; i < lay.ec; i++ {
+		for _, block := range ecBlocks {
+			out = append(out, block[i])
+		}
+	}
+	return out
+}
+
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*b = append(*b, value>>i&1 == 1)
+	}
+}
+
+func (b bitBuffer) bytes() []byte {
+	out := make([]byte, (len(b)+7)/8)
+	for i, bit := range b {
+		if bit {
+			out[i/8] |= 0x80 >> (i % 8)
+		}
+	}
+	return out
+}
@@ -0,0 +1,285 @@
+package qr
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"testing"
+)
+
+// rawCodewords is how many codewords each version holds, data and EC
+// together, and remainderBits the unused modules left after them (ISO
+// 18004 table 1)
+var (
+	rawCodewords  = [15]int{26, 44, 70, 100, 134, 172, 196, 242, 292, 346, 404, 466, 532, 581, 655}
+	remainderBits = [15]int{0, 7, 7, 7, 7, 7, 0, 0, 0, 0, 0, 0, 0, 3, 3}
+)
+
+// byteCapacity is the byte mode capacity of each version (ISO 18004 table 7)
+var byteCapacity = map[Level][15]int{
+	M: {14, 26, 42, 62, 84, 106, 122, 152, 180, 213, 251, 287, 331, 362, 412},
+	L: {17, 32, 53, 78, 106, 134, 154, 192, 230, 271, 321, 367, 425, 458, 520},
+}
+
+func TestLayouts(t *testing.T) {
+	for _, level := range []Level{M, L} {
+		for version := 1; version <= maxVersion; version++ {
+			lay := layouts[level][version-1]
+			if got := lay.dataCodewords() + lay.ec*(lay.g1+lay.g2); got != rawCodewords[version-1] {
+				t.Errorf("version %d level %d has %d codewords, want %d", version, level, got, rawCodewords[version-1])
+			}
+			if got := capacity(version, level); got != byteCapacity[level][version-1] {
+				t.Errorf("version %d level %d holds %d bytes, want %d", version, level, got, byteCapacity[level][version-1])
+			}
+
+			// Whatever function patterns don't cover is exactly the room
+			// the codewords need
+			m := newCode(version)
+			m.drawFunctionPatterns(level)
+			free := 0
+			for y := range m.function {
+				for x := range m.function[y] {
+					if !m.function[y][x] {
+						free++
+					}
+				}
+			}
+			if want := rawCodewords[version-1]*8 + remainderBits[version-1]; free != want {
+				t.Errorf("version %d leaves %d modules for data, want %d", version, free, want)
+			}
+		}
+	}
+}
+
+func TestReedSolomon(t *testing.T) {
+	// Generator polynomials from the spec's annex A
+	generators := map[int][]byte{
+		7:  {127, 122, 154, 164, 11, 68, 117},
+		10: {216, 194, 159, 111, 199, 94, 95, 113, 157, 193},
+	}
+	for degree, want := range generators {
+		if got := rsDivisor(degree); !bytes.Equal(got, want) {
+			t.Errorf("generator of degree %d = %v, want %v", degree, got, want)
+		}
+	}
+
+	// "HELLO WORLD" as 1-M in alphanumeric mode, the textbook example
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	if got := rsRemainder(data, rsDivisor(10)); !bytes.Equal(got, want) {
+		t.Errorf("EC codewords = %v, want %v", got, want)
+	}
+}
+
+func TestFormatBits(t *testing.T) {
+	// ISO 18004 table C.1, most significant bit first
+	want := map[Level][8]string{
+		L: {"111011111000100", "111001011110011", "111110110101010", "111100010011101",
+			"110011000101111", "110001100011000", "110110001000001", "110100101110110"},
+		M: {"101010000010010", "101000100100101", "101111001111100", "101101101001011",
+			"100010111111001", "100000011001110", "100111110010111", "100101010100000"},
+	}
+	for level, masks := range want {
+		for mask, bits := range masks {
+			m := newCode(1)
+			m.drawFormat(level, mask)
+			first, second := readFormat(func(x, y int) bool { return m.modules[y][x] }, m.size)
+			if first != bits || second != bits {
+				t.Errorf("level %d mask %d drawn as %s and %s, want %s", level, mask, first, second, bits)
+			}
+		}
+	}
+}
+
+func TestVersionBits(t *testing.T) {
+	// ISO 18004 table D.1
+	want := []int{0x07C94, 0x085BC, 0x09A99, 0x0A4D3, 0x0BBF6, 0x0C762, 0x0D847, 0x0E60D, 0x0F928}
+	for i, bits := range want {
+		version := i + 7
+		m := newCode(version)
+		m.drawVersion()
+
+		// Bit 0 is the top left module of the block left of the top right
+		// finder, three bits to a row; the bottom left block is its mirror
+		var below, right int
+		for j := 0; j < 18; j++ {
+			a, b := m.size-11+j%3, j/3
+			if m.modules[b][a] {
+				right |= 1 << j
+			}
+			if m.modules[a][b] {
+				below |= 1 << j
+			}
+		}
+		if right != bits || below != bits {
+			t.Errorf("version %d drawn as %05X and %05X, want %05X", version, right, below, bits)
+		}
+	}
+}
+
+func TestCodewords(t *testing.T) {
+	// Small symbols are checked codeword by codeword
+	small := []struct {
+		level Level
+		want  []byte
+	}{
+		{M, []byte{64, 38, 134, 144, 236, 17, 236, 17, 236, 17, 236, 17, 236, 17, 236, 17, 17, 160, 77, 193, 121, 155, 5, 133, 245, 218}},
+		{L, []byte{64, 38, 134, 144, 236, 17, 236, 17, 236, 17, 236, 17, 236, 17, 236, 17, 236, 17, 236, 244, 219, 18, 20, 171, 219, 56}},
+	}
+	for _, tt := range small {
+		code := encode([]byte("hi"), 1, tt.level)
+		if got := readCodewords(t, code, tt.level); !bytes.Equal(got, tt.want) {
+			t.Errorf("1-%d codewords = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+
+	// Bigger ones, full of text, cover several blocks and both groups.
+	// The digests are of every codeword in order, from an independent
+	// encoder.
+	big := []struct {
+		version int
+		level   Level
+		digest  string
+	}{
+		{5, M, "061ea40c6fd41b2b68c3fac728df9866e2a582df9ac5f86cc9e21b5ebab3d750"},
+		{10, L, "eb16cd70028816e1ca6110a100c2ce602066ea3af5369eaa4ab375c781eeceb1"},
+		{14, M, "4a5506bdd9f30e31e61703a57b8e366900e0620aec85b130f87aa1fad66fe969"},
+		{15, L, "97ea67790e64595b796f793502423e3291ad3fea1c927c3677b1c7ab65dbade8"},
+	}
+	for _, tt := range big {
+		data := bytes.Repeat([]byte("pcstyle.dev over ssh! "), 50)[:capacity(tt.version, tt.level)]
+		got := readCodewords(t, encode(data, tt.version, tt.level), tt.level)
+		sum := sha256.Sum256(got)
+		if len(got) != rawCodewords[tt.version-1] || hex.EncodeToString(sum[:]) != tt.digest {
+			t.Errorf("%d-%d codewords don't match (%d of them)", tt.version, tt.level, len(got))
+		}
+	}
+}
+
+func TestEncodeLimits(t *testing.T) {
+	for _, tt := range []struct {
+		n       int
+		version int
+	}{
+		{0, 1},
+		{14, 1},
+		{15, 2},
+		{412, 15}, // the most M holds
+		{413, 13}, // then L, which fits it in a smaller version
+		{520, 15},
+	} {
+		code, err := Encode(make([]byte, tt.n))
+		if err != nil {
+			t.Errorf("%d bytes: %v", tt.n, err)
+			continue
+		}
+		if code.Version != tt.version || code.Size != tt.version*4+17 {
+			t.Errorf("%d bytes went into version %d (size %d), want %d", tt.n, code.Version, code.Size, tt.version)
+		}
+	}
+
+	if _, err := Encode(make([]byte, 521)); err != ErrTooLong {
+		t.Errorf("521 bytes: %v, want ErrTooLong", err)
+	}
+}
+
+// readFormat reads both copies of the format bits, most significant first
+func readFormat(dark func(x, y int) bool, size int) (string, string) {
+	var first, second []byte
+	bit := func(b []byte, x, y int) []byte {
+		if dark(x, y) {
+			return append(b, '1')
+		}
+		return append(b, '0')
+	}
+
+	// Around the top left finder: along row 8, then up column 8, both
+	// skipping the timing pattern
+	for x := 0; x <= 5; x++ {
+		first = bit(first, x, 8)
+	}
+	first = bit(first, 7, 8)
+	first = bit(first, 8, 8)
+	first = bit(first, 8, 7)
+	for y := 5; y >= 0; y-- {
+		first = bit(first, 8, y)
+	}
+
+	// Up from the bottom left finder, then along the top right one
+	for y := size - 1; y >= size-7; y-- {
+		second = bit(second, 8, y)
+	}
+	for x := size - 8; x < size; x++ {
+		second = bit(second, x, 8)
+	}
+	return string(first), string(second)
+}
+
+// readCodewords scans code the way a reader would: format bits for the
+// mask, then the data modules in zigzag order with the mask undone
+func readCodewords(t *testing.T, code *Code, level Level) []byte {
+	t.Helper()
+	first, second := readFormat(code.Dark, code.Size)
+	if first != second {
+		t.Fatalf("format copies disagree: %s and %s", first, second)
+	}
+	format, _ := strconv.ParseInt(first, 2, 0)
+	format ^= 0x5412
+	// Level bits: L is 01, M is 00
+	if want := map[Level]int64{M: 0, L: 1}[level]; format>>13 != want {
+		t.Fatalf("symbol has level bits %02b, want %02b", format>>13, want)
+	}
+	mask := int(format >> 10 & 7)
+
+	// Which modules hold data depends only on the version
+	layout := newCode(code.Version)
+	layout.drawFunctionPatterns(level)
+
+	// Column pairs from the right, the first one going up, hopping over
+	// the vertical timing pattern
+	var bits bitBuffer
+	upward := true
+	for right := code.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for i := 0; i < code.Size; i++ {
+			y := i
+			if upward {
+				y = code.Size - 1 - i
+			}
+			for x := right; x >= right-1; x-- {
+				if layout.function[y][x] {
+					continue
+				}
+				bits = append(bits, code.Dark(x, y) != masked(mask, x, y))
+			}
+		}
+		upward = !upward
+	}
+	return bits.bytes()[:rawCodewords[code.Version-1]]
+}
+
+// masked is the mask patterns as the spec writes them, i for rows and j
+// for columns
+func masked(mask, j, i int) bool {
+	switch mask {
+	case 0:
+		return (i+j)%2 == 0
+	case 1:
+		return i%2 == 0
+	case 2:
+		return j%3 == 0
+	case 3:
+		return (i+j)%3 == 0
+	case 4:
+		return (i/2+j/3)%2 == 0
+	case 5:
+		return (i*j)%2+(i*j)%3 == 0
+	case 6:
+		return ((i*j)%2+(i*j)%3)%2 == 0
+	default:
+		return ((i+j)%2+(i*j)%3)%2 == 0
+	}
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/pcstyle/ssh-server/internal/store"
+)
+
+// replyRequest is the body of POST /inbox/reply
+type replyRequest struct {
+	Reference string `json:"reference"`
+	Message   string `json:"message"`
+}
+
+// adminHandler serves the owner-facing callback API:
+//
+//	GET  /inbox        threads waiting for a reply
+//	POST /inbox/reply  {"reference": "SSH-...", "message": "..."}
+//
+// Every request needs "Authorization: Bearer <admin token>".
+func (s *Server) adminHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/inbox", s.handlePending)
+	mux.HandleFunc("/inbox/reply", s.handleReply)
+	return s.requireToken(mux)
+}
+
+// requireToken rejects requests without the configured bearer token
+func (s *Server) requireToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.config.AdminToken)) != 1 {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handlePending(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	threads := s.inbox.Pending()
+	if threads == nil {
+		threads = []store.Thread{}
+	}
+	writeJSON(w, http.StatusOK, threads)
+}
+
+func (s *Server) handleReply(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	var req replyRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10)).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON"})
+		return
+	}
+
+	req.Message = strings.TrimSpace(req.Message)
+	if req.Reference == "" || req.Message == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "reference and message are required"})
+		return
+	}
+
+	if err := s.inbox.Reply(req.Reference, req.Message); err != nil {
+		if errors.Is(err, store.ErrThreadNotFound) {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "unknown reference"})
+			return
+		}
+		log.Error("Failed to store reply", "reference", req.Reference, "error", err)
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to store reply"})
+		return
+	}
+
+	log.Info("Reply stored", "reference", req.Reference)
+	writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Warn("Failed to write response", "error", err)
+	}
+}
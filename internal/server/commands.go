@@ -0,0 +1,77 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	"github.com/pcstyle/ssh-server/internal/ui"
+)
+
+// commandHelp is printed by `help`, one command per line
+const commandHelp = `Commands:
+  help         show this list
+  pages        list the content pages
+  page <slug>  print a page as Markdown
+
+Connect without a command for the full interface.
+`
+
+// commandMiddleware answers `ssh host <command>` with plain text instead of
+// starting the UI, for scripts and terminals that can't run it. Sessions
+// without a command go on to the UI.
+func (s *Server) commandMiddleware(next ssh.Handler) ssh.Handler {
+	return func(sess ssh.Session) {
+		args := sess.Command()
+		if len(args) == 0 {
+			next(sess)
+			return
+		}
+
+		if err := s.runCommand(sess, ui.DetectLocale(sess.Environ()), args); err != nil {
+			wish.Fatalln(sess, err)
+			return
+		}
+		_ = sess.Exit(0)
+	}
+}
+
+// runCommand writes the output of args to w; pages are picked in locale
+func (s *Server) runCommand(w io.Writer, locale ui.Locale, args []string) error {
+	switch args[0] {
+	case "help":
+		_, err := io.WriteString(w, commandHelp)
+		return err
+
+	case "pages":
+		for _, page := range s.pages.List(string(locale)) {
+			if page.Hidden {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "%-12s %s\n", page.Slug, page.MenuTitle()); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case "page":
+		if len(args) != 2 {
+			return errors.New("usage: page <slug>")
+		}
+		page, ok := s.pages.Page(args[1], string(locale))
+		if !ok || page.Hidden {
+			return fmt.Errorf("no page called %q, try: pages", args[1])
+		}
+		body := page.Body
+		if !strings.HasSuffix(body, "\n") {
+			body += "\n"
+		}
+		_, err := io.WriteString(w, body)
+		return err
+	}
+
+	return fmt.Errorf("unknown command %q, try: help", strings.Join(args, " "))
+}
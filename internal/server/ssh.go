@@ -2,9 +2,16 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -15,32 +22,148 @@ import (
 	"github.com/charmbracelet/wish"
 	"github.com/charmbracelet/wish/bubbletea"
 	"github.com/charmbracelet/wish/logging"
+	"github.com/muesli/termenv"
+	"github.com/pcstyle/ssh-server/internal/arena"
+	"github.com/pcstyle/ssh-server/internal/content"
+	"github.com/pcstyle/ssh-server/internal/projects"
+	"github.com/pcstyle/ssh-server/internal/store"
 	"github.com/pcstyle/ssh-server/internal/ui"
+	gossh "golang.org/x/crypto/ssh"
 )
 
 // Config holds the server configuration
 type Config struct {
 	Host       string
 	Port       int
+	HostKey    string // Path to the host key, created if missing (.ssh/id_ed25519 by default)
 	APIBaseURL string
+	DataDir    string
+	DraftTTL   time.Duration
+	AdminAddr  string
+	AdminToken string
+	ThemeDir   string
+	ContentDir string
+	FeedURL    string
+
+	// Disabled screens, by registration name (blog, arcade, pages, ...)
+	Disabled []string
+
+	// StatusInterval is how often project URLs are checked
+	StatusInterval time.Duration
+
+	// ShutdownTimeout is how long sessions get to finish on shutdown
+	// before they're cut off, 30 seconds if zero
+	ShutdownTimeout time.Duration
 }
 
 // Server represents the SSH server
 type Server struct {
-	config Config
-	ssh    *ssh.Server
+	config  Config
+	clock   ui.Clock
+	ssh     *ssh.Server
+	drafts  *store.Drafts
+	inbox   *store.Inbox
+	feeds   *store.FeedCache
+	scores  *store.Leaderboard
+	arena   *arena.Hub
+	admin   *http.Server
+	themes  []ui.Palette
+	pages   *content.Library
+	secrets *content.Library
+	// projects come from the content dir, monitor checks their URLs
+	projects []projects.Project
+	monitor  *projects.Monitor
 }
 
 // NewServer creates a new SSH server
 func NewServer(config Config) (*Server, error) {
 	s := &Server{
 		config: config,
+		clock:  ui.SystemClock{},
+	}
+
+	// Contact drafts survive disconnects, so they live on disk. They expire
+	// by the same clock sessions stamp them with.
+	drafts, err := store.NewDrafts(filepath.Join(config.DataDir, "drafts"), config.DraftTTL, s.clock.Now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open draft store: %w", err)
+	}
+	s.drafts = drafts
+
+	// Conversations with key-authenticated visitors
+	inbox, err := store.NewInbox(filepath.Join(config.DataDir, "inbox"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open inbox: %w", err)
+	}
+	s.inbox = inbox
+
+	// Last good copy of the blog feed, served when the site can't be reached
+	feeds, err := store.NewFeedCache(filepath.Join(config.DataDir, "feeds"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open feed cache: %w", err)
+	}
+	s.feeds = feeds
+
+	// Arcade high scores, checked against what the games can actually score
+	scores, err := store.NewLeaderboard(filepath.Join(config.DataDir, "scores.json"), ui.ArcadeRules())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open leaderboard: %w", err)
+	}
+	s.scores = scores
+
+	// Multiplayer snake rooms, shared by everyone connected
+	s.arena = arena.NewHub(arena.Config{})
+
+	// User themes, offered after the built-in ones
+	themes, err := ui.LoadPalettes(config.ThemeDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load themes: %w", err)
+	}
+	s.themes = themes
+
+	// Markdown pages for the menu, plus the hidden logbook
+	if s.pages, err = content.Load(config.ContentDir); err != nil {
+		return nil, fmt.Errorf("failed to load content: %w", err)
+	}
+	secretsDir := ""
+	if config.ContentDir != "" {
+		secretsDir = filepath.Join(config.ContentDir, "secrets")
+	}
+	if s.secrets, err = content.Load(secretsDir); err != nil {
+		return nil, fmt.Errorf("failed to load secrets: %w", err)
+	}
+
+	// Projects browser data, with status checks shared by every session
+	projectsFile := ""
+	if config.ContentDir != "" {
+		projectsFile = filepath.Join(config.ContentDir, "projects.json")
+	}
+	if s.projects, err = projects.Load(projectsFile); err != nil {
+		return nil, fmt.Errorf("failed to load projects: %w", err)
+	}
+	s.monitor = projects.NewMonitor(s.projects, config.StatusInterval)
+
+	// Owner callback API for replies, only with a token set
+	if config.AdminAddr != "" {
+		if config.AdminToken == "" {
+			return nil, fmt.Errorf("admin API on %s needs an admin token", config.AdminAddr)
+		}
+		s.admin = &http.Server{
+			Addr:              config.AdminAddr,
+			Handler:           s.adminHandler(),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+	}
+
+	hostKey := config.HostKey
+	if hostKey == "" {
+		hostKey = ".ssh/id_ed25519"
 	}
 
 	// Create the SSH server with Wish middleware
 	sshServer, err := wish.NewServer(
 		wish.WithAddress(fmt.Sprintf("%s:%d", config.Host, config.Port)),
-		wish.WithHostKeyPath(".ssh/id_ed25519"),
+		wish.WithHostKeyPath(hostKey),
 		wish.WithPublicKeyAuth(func(ctx ssh.Context, key ssh.PublicKey) bool {
 			// Allow all connections (public access)
 			return true
@@ -51,6 +174,7 @@ func NewServer(config Config) (*Server, error) {
 		}),
 		wish.WithMiddleware(
 			bubbletea.Middleware(s.teaHandler),
+			s.commandMiddleware,
 			logging.Middleware(),
 		),
 	)
@@ -68,48 +192,174 @@ func (s *Server) teaHandler(sshSession ssh.Session) (tea.Model, []tea.ProgramOpt
 	pty, _, _ := sshSession.Pty()
 	log.Info("Terminal", "type", pty.Term, "width", pty.Window.Width, "height", pty.Window.Height)
 
-	// Set up Lip Gloss renderer with color support for this output
-	renderer := lipgloss.NewRenderer(sshSession)
+	// Set up Lip Gloss renderer for this output. Color support is detected
+	// from the client's TERM and environment, not the server's.
+	env := sessionEnviron(append(sshSession.Environ(), "TERM="+pty.Term))
+	renderer := lipgloss.NewRenderer(sshSession, termenv.WithEnvironment(env), termenv.WithUnsafe(), termenv.WithColorCache(true))
+	if pty.Term == "" || pty.Term == "dumb" {
+		renderer.SetColorProfile(termenv.Ascii)
+	}
 	renderer.SetHasDarkBackground(true)
 
+	// Pick a rendering tier so old terminals get something readable
+	caps := ui.DetectCapabilities(pty.Term, sshSession.Environ(), renderer.ColorProfile())
+	log.Info("Capabilities", "colors", caps.Color, "ascii", caps.ASCII, "hyperlinks", caps.Hyperlinks, "clipboard", caps.Clipboard)
+
+	// Frames and clipboard sequences share one writer, so they can't interleave
+	out := &lockedWriter{w: sshSession}
+
+	// Identify returning visitors by their public key, if they used one
+	session := ui.Session{
+		Fingerprint: fingerprint(sshSession.PublicKey()),
+		Env:         sshSession.Environ(),
+		Caps:        caps,
+		Terminal:    out,
+		Clock:       s.clock,
+		Drafts:      s.drafts,
+		Inbox:       s.inbox,
+		Scores:      s.scores,
+		Arena:       s.arena,
+		Done:        sshSession.Context().Done(),
+		Themes:      s.themes,
+		Pages:       s.pages,
+		Secrets:     s.secrets,
+		FeedURL:     s.config.FeedURL,
+		Feeds:       s.feeds,
+		Projects:    s.projects,
+		Monitor:     s.monitor,
+		Disabled:    s.config.Disabled,
+	}
+
 	// Create a new app model for this session with the renderer
-	model := ui.NewModel(s.config.APIBaseURL, renderer)
+	model := ui.NewModel(s.config.APIBaseURL, renderer, session)
 
 	// Configure the Bubble Tea program with proper I/O
 	opts := []tea.ProgramOption{
 		tea.WithAltScreen(),
-		tea.WithMouseCellMotion(),
+		// All motion, not just drags, so buttons can light up under the pointer
+		tea.WithMouseAllMotion(),
 		tea.WithInput(sshSession),
-		tea.WithOutput(sshSession),
+		tea.WithOutput(out),
 	}
 
 	return model, opts
 }
 
-// Start starts the SSH server
+// lockedWriter serializes writes to a session. Bubble Tea writes each frame
+// in a single call, so other writers only ever land between frames.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
+// sessionEnviron exposes the client's environment to termenv
+type sessionEnviron []string
+
+func (e sessionEnviron) Environ() []string { return e }
+
+func (e sessionEnviron) Getenv(key string) string {
+	// Later entries win, so the PTY's TERM overrides anything sent with env
+	value := ""
+	for _, kv := range e {
+		if k, v, ok := strings.Cut(kv, "="); ok && k == key {
+			value = v
+		}
+	}
+	return value
+}
+
+// fingerprint returns the SHA256 fingerprint of key, or "" for password logins
+func fingerprint(key ssh.PublicKey) string {
+	if key == nil {
+		return ""
+	}
+	return gossh.FingerprintSHA256(key)
+}
+
+// Start listens on the configured address and serves until the process
+// is interrupted
 func (s *Server) Start() error {
-	done := make(chan os.Signal, 1)
-	signal.Notify(done, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// Start the server in a goroutine
+	ln, err := net.Listen("tcp", s.ssh.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	return s.Serve(ctx, ln)
+}
+
+// Serve accepts SSH connections on ln until ctx is done, then shuts down:
+// new connections are refused and open sessions get ShutdownTimeout to
+// finish before they're closed. The admin API, if configured, runs
+// alongside on its own address.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	// Project status checks, arena rooms and the draft sweep run until
+	// shutdown
+	checks, stopChecks := context.WithCancel(ctx)
+	defer stopChecks()
+	go s.monitor.Run(checks)
+	go s.arena.Run(checks)
+	go s.drafts.Run(checks)
+
+	serveErr := make(chan error, 1)
 	go func() {
-		log.Info("Starting SSH server", "host", s.config.Host, "port", s.config.Port)
-		if err := s.ssh.ListenAndServe(); err != nil {
-			log.Error("SSH server error", "error", err)
-		}
+		log.Info("Starting SSH server", "addr", ln.Addr())
+		serveErr <- s.ssh.Serve(ln)
 	}()
 
-	// Wait for interrupt signal
-	<-done
+	if s.admin != nil {
+		go func() {
+			log.Info("Starting admin API", "addr", s.config.AdminAddr)
+			if err := s.admin.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Error("Admin API error", "error", err)
+			}
+		}()
+	}
+
+	select {
+	case <-ctx.Done():
+	case err := <-serveErr:
+		// The listener broke, nothing left to serve
+		s.closeAdmin()
+		return fmt.Errorf("SSH server error: %w", err)
+	}
 
 	log.Info("Shutting down SSH server...")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	timeout := s.config.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	if err := s.ssh.Shutdown(ctx); err != nil {
-		return fmt.Errorf("failed to shutdown server: %w", err)
+	if s.admin != nil {
+		if err := s.admin.Shutdown(shutdownCtx); err != nil {
+			log.Error("Failed to shutdown admin API", "error", err)
+		}
+	}
+
+	if err := s.ssh.Shutdown(shutdownCtx); err != nil {
+		// Sessions still open past the timeout are cut off
+		log.Warn("Closing sessions left after shutdown timeout", "error", err)
+		if err := s.ssh.Close(); err != nil {
+			return fmt.Errorf("failed to shutdown server: %w", err)
+		}
 	}
 
 	log.Info("Server stopped")
 	return nil
 }
+
+// closeAdmin stops the admin API right away
+func (s *Server) closeAdmin() {
+	if s.admin != nil {
+		_ = s.admin.Close()
+	}
+}
@@ -0,0 +1,377 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pcstyle/ssh-server/internal/api"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// These tests run the real server on a loopback port and talk to it with
+// an SSH client, the way a visitor would.
+
+// testServer is a running server and how to reach it
+type testServer struct {
+	addr    string
+	hostKey gossh.PublicKey
+	stop    context.CancelFunc
+	done    chan error
+}
+
+// startServer serves a fresh data and content dir on an ephemeral port,
+// posting contact messages to apiURL. It's stopped when the test ends.
+func startServer(t *testing.T, apiURL string) *testServer {
+	t.Helper()
+	dir := t.TempDir()
+	contentDir := filepath.Join(dir, "content")
+	if err := os.MkdirAll(contentDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	about := "---\ntitle: About\norder: 1\n---\nHi, I write **Go** over SSH.\n"
+	if err := os.WriteFile(filepath.Join(contentDir, "about.md"), []byte(about), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	srv, err := NewServer(Config{
+		Host:            "127.0.0.1",
+		HostKey:         filepath.Join(dir, "host_ed25519"),
+		APIBaseURL:      apiURL,
+		DataDir:         filepath.Join(dir, "data"),
+		DraftTTL:        time.Hour,
+		ContentDir:      contentDir,
+		StatusInterval:  time.Hour,
+		ShutdownTimeout: time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pub, err := os.ReadFile(filepath.Join(dir, "host_ed25519.pub"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostKey, _, _, _, err := gossh.ParseAuthorizedKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, stop := context.WithCancel(context.Background())
+	ts := &testServer{addr: ln.Addr().String(), hostKey: hostKey, stop: stop, done: make(chan error, 1)}
+	go func() { ts.done <- srv.Serve(ctx, ln) }()
+	t.Cleanup(func() { ts.shutdown(t) })
+	return ts
+}
+
+// shutdown stops the server and waits for Serve to return
+func (ts *testServer) shutdown(t *testing.T) error {
+	t.Helper()
+	ts.stop()
+	select {
+	case err := <-ts.done:
+		ts.done <- err // later calls see the same result
+		return err
+	case <-time.After(10 * time.Second):
+		t.Fatal("server didn't shut down")
+		return nil
+	}
+}
+
+// dial connects as a password visitor, checking the server's host key
+func (ts *testServer) dial(t *testing.T) *gossh.Client {
+	t.Helper()
+	client, err := gossh.Dial("tcp", ts.addr, &gossh.ClientConfig{
+		User:            "visitor",
+		Auth:            []gossh.AuthMethod{gossh.Password("")},
+		HostKeyCallback: gossh.FixedHostKey(ts.hostKey),
+		Timeout:         5 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// terminal is an interactive session with a PTY
+type terminal struct {
+	t       *testing.T
+	session *gossh.Session
+	stdin   io.Writer
+	out     *screen
+}
+
+// screen collects everything the server sent. seen marks how far expect
+// has matched, so each expectation only looks at newer output.
+type screen struct {
+	mu   sync.Mutex
+	buf  bytes.Buffer
+	seen int
+}
+
+func (s *screen) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.Write(p)
+}
+
+// find looks for text after the last match and moves past it
+func (s *screen) find(text string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := strings.Index(s.buf.String()[s.seen:], text)
+	if i < 0 {
+		return false
+	}
+	s.seen += i + len(text)
+	return true
+}
+
+// since is the output after offset, a length String had earlier
+func (s *screen) since(offset int) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.String()[offset:]
+}
+
+func (s *screen) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.String()
+}
+
+// openTerminal starts the UI in a width x height PTY
+func openTerminal(t *testing.T, client *gossh.Client, width, height int) *terminal {
+	t.Helper()
+	session, err := client.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { session.Close() })
+
+	if err := session.RequestPty("xterm-256color", height, width, gossh.TerminalModes{gossh.ECHO: 0}); err != nil {
+		t.Fatal(err)
+	}
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := &screen{}
+	session.Stdout = out
+	if err := session.Shell(); err != nil {
+		t.Fatal(err)
+	}
+	return &terminal{t: t, session: session, stdin: stdin, out: out}
+}
+
+// send types keys, "\r" is enter and "\t" tab
+func (term *terminal) send(keys string) {
+	term.t.Helper()
+	if _, err := io.WriteString(term.stdin, keys); err != nil {
+		term.t.Fatal(err)
+	}
+}
+
+// expect waits for text to show up in the output
+func (term *terminal) expect(text string) {
+	term.t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !term.out.find(text) {
+		if time.Now().After(deadline) {
+			term.t.Fatalf("%q never showed up, output so far:\n%q", text, term.out.String())
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// fakeAPI accepts contact messages, answering like the real API does
+type fakeAPI struct {
+	*httptest.Server
+	mu       sync.Mutex
+	received []api.ContactRequest
+}
+
+func newFakeAPI(t *testing.T) *fakeAPI {
+	t.Helper()
+	f := &fakeAPI{}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req api.ContactRequest
+		if r.URL.Path != "/api/contact" || json.NewDecoder(r.Body).Decode(&req) != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		f.mu.Lock()
+		f.received = append(f.received, req)
+		f.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(api.ContactResponse{Success: true, Message: "Got it, thanks"})
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeAPI) messages() []api.ContactRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]api.ContactRequest(nil), f.received...)
+}
+
+func TestContactOverSSH(t *testing.T) {
+	fake := newFakeAPI(t)
+	ts := startServer(t, fake.URL)
+	term := openTerminal(t, ts.dial(t), 100, 40)
+
+	term.expect("Contact")
+	term.send("\r")
+	term.expect("Message")
+	term.send("Hello over SSH\tAda\tada@example.com")
+	term.expect("ada@example.com")
+	// Past discord, phone and facebook to the submit button, then review
+	term.send("\t\t\t\t\r")
+	term.expect("Send")
+	term.send("\r")
+	term.expect("Got it, thanks")
+
+	// The message carries the reference the receipt shows
+	got := fake.messages()
+	if len(got) != 1 {
+		t.Fatalf("API got %+v, want one message", got)
+	}
+	ref, _, _ := strings.Cut(strings.TrimPrefix(got[0].Message, "["), "]")
+	term.expect(ref)
+	want := api.ContactRequest{Message: api.TagMessage(ref, "Hello over SSH"), Name: "Ada", Email: "ada@example.com", Source: "ssh"}
+	if got[0] != want || !strings.HasPrefix(ref, "SSH-") {
+		t.Fatalf("API got %+v, want %+v", got[0], want)
+	}
+}
+
+func TestWindowResize(t *testing.T) {
+	ts := startServer(t, "http://127.0.0.1:0")
+	term := openTerminal(t, ts.dial(t), 100, 40)
+	term.expect("Contact")
+	if !strings.Contains(term.out.String(), "╔═") {
+		t.Fatalf("no boxed banner on a 100x40 terminal: %q", term.out.String())
+	}
+
+	// Too small for the boxed banner, the repaint has the plain one
+	before := len(term.out.String())
+	if err := term.session.WindowChange(20, 60); err != nil {
+		t.Fatal(err)
+	}
+	term.expect("P C S T Y L E")
+	if frame := term.out.since(before); strings.Contains(frame, "╔═") {
+		t.Fatalf("boxed banner after shrinking to 60x20: %q", frame)
+	}
+}
+
+func TestExecCommands(t *testing.T) {
+	ts := startServer(t, "http://127.0.0.1:0")
+	client := ts.dial(t)
+
+	run := func(command string) (string, error) {
+		t.Helper()
+		session, err := client.NewSession()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer session.Close()
+		out, err := session.CombinedOutput(command)
+		return string(out), err
+	}
+
+	out, err := run("pages")
+	if err != nil || !strings.Contains(out, "about") || !strings.Contains(out, "About") {
+		t.Errorf("pages = %q, %v", out, err)
+	}
+
+	out, err = run("page about")
+	if err != nil || out != "Hi, I write **Go** over SSH.\n" {
+		t.Errorf("page about = %q, %v", out, err)
+	}
+
+	out, err = run("page nope")
+	var exit *gossh.ExitError
+	if !errors.As(err, &exit) || exit.ExitStatus() != 1 || !strings.Contains(out, `no page called "nope"`) {
+		t.Errorf("page nope = %q, %v", out, err)
+	}
+
+	out, err = run("rm -rf /")
+	if !errors.As(err, &exit) || exit.ExitStatus() != 1 || !strings.Contains(out, "try: help") {
+		t.Errorf("unknown command = %q, %v", out, err)
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	ts := startServer(t, "http://127.0.0.1:0")
+	term := openTerminal(t, ts.dial(t), 80, 24)
+	term.expect("Contact")
+
+	// The open session outlives ShutdownTimeout and gets cut off
+	if err := ts.shutdown(t); err != nil {
+		t.Fatalf("Serve returned %v", err)
+	}
+
+	waited := make(chan error, 1)
+	go func() { waited <- term.session.Wait() }()
+	select {
+	case <-waited:
+	case <-time.After(5 * time.Second):
+		t.Fatal("session still open after shutdown")
+	}
+
+	if conn, err := net.DialTimeout("tcp", ts.addr, time.Second); err == nil {
+		conn.Close()
+		t.Fatal("server still accepting connections after shutdown")
+	}
+}
+
+func TestMultiplayerOverSSH(t *testing.T) {
+	ts := startServer(t, "http://127.0.0.1:0")
+
+	// Both unlock the arcade and quick match from the lobby
+	join := func() *terminal {
+		term := openTerminal(t, ts.dial(t), 100, 40)
+		term.expect("Contact")
+		// A key at a time, one write would arrive as a single paste
+		for _, c := range "snake" {
+			term.send(string(c))
+			time.Sleep(20 * time.Millisecond)
+		}
+		term.expect("SNAKE VS")
+		term.expect("arcade ready")
+		term.send("j\r")
+		term.expect("QUICK MATCH")
+		term.send("\r")
+		term.expect("room #1")
+		return term
+	}
+	ada := join()
+	ada.expect("waiting for someone to play with")
+	bob := join()
+
+	// Same room, and each one sees the other's snake
+	bob.expect("round 1 starts in")
+	ada.expect("P2  0 pts")
+	ada.expect("round 1 starts in")
+
+	// Ada walks out before the start, Bob waits for someone new
+	ada.send("\x1b")
+	ada.expect("left the room")
+	bob.expect("waiting for someone to play with")
+}
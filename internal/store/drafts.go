@@ -0,0 +1,188 @@
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/pcstyle/ssh-server/internal/api"
+)
+
+// DefaultDraftTTL is how long an unsent draft is kept around
+const DefaultDraftTTL = 24 * time.Hour
+
+// draftSweepInterval is how often Run clears out expired drafts. Drafts
+// hold contact details, they shouldn't outlive their TTL by much.
+const draftSweepInterval = 15 * time.Minute
+
+// Draft is an unsent contact message, stored exactly as typed
+type Draft struct {
+	Request api.ContactRequest `json:"request"`
+	SavedAt time.Time          `json:"saved_at"`
+}
+
+// Drafts persists contact drafts on disk, keyed by key fingerprint or resume code
+type Drafts struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+	mu  sync.Mutex
+}
+
+// NewDrafts creates a draft store in dir. A zero ttl means DefaultDraftTTL.
+// now is the clock drafts are stamped and expired by, it has to be the one
+// sessions stamp SavedAt with; nil means time.Now.
+func NewDrafts(dir string, ttl time.Duration, now func() time.Time) (*Drafts, error) {
+	if ttl <= 0 {
+		ttl = DefaultDraftTTL
+	}
+	if now == nil {
+		now = time.Now
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	d := &Drafts{dir: dir, ttl: ttl, now: now}
+	d.Sweep()
+	return d, nil
+}
+
+// Save stores the draft for key, replacing any older one
+func (d *Drafts) Save(key string, draft Draft) error {
+	if d == nil || key == "" {
+		return nil
+	}
+	if draft.SavedAt.IsZero() {
+		draft.SavedAt = d.now()
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return saveJSON(keyFile(d.dir, key), draft)
+}
+
+// Load returns the draft for key if there is one that hasn't expired
+func (d *Drafts) Load(key string) (Draft, bool) {
+	if d == nil || key == "" {
+		return Draft{}, false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var draft Draft
+	path := keyFile(d.dir, key)
+	if err := loadJSON(path, &draft); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Warn("Failed to load draft", "error", err)
+		}
+		return Draft{}, false
+	}
+
+	if d.expired(draft) {
+		os.Remove(path)
+		return Draft{}, false
+	}
+	return draft, true
+}
+
+// Delete wipes the draft for key, e.g. once it has been sent
+func (d *Drafts) Delete(key string) error {
+	if d == nil || key == "" {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	err := os.Remove(keyFile(d.dir, key))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Run sweeps expired drafts away periodically until ctx is done
+func (d *Drafts) Run(ctx context.Context) {
+	if d == nil {
+		return
+	}
+
+	ticker := time.NewTicker(min(d.ttl, draftSweepInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.Sweep()
+		}
+	}
+}
+
+// Sweep removes every expired draft
+func (d *Drafts) Sweep() {
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	files, err := filepath.Glob(filepath.Join(d.dir, "*.json"))
+	if err != nil {
+		return
+	}
+
+	for _, path := range files {
+		var draft Draft
+		if err := loadJSON(path, &draft); err != nil || d.expired(draft) {
+			os.Remove(path)
+		}
+	}
+}
+
+// expired reports whether draft is older than the TTL
+func (d *Drafts) expired(draft Draft) bool {
+	return d.now().Sub(draft.SavedAt) > d.ttl
+}
+
+// resumeAlphabet skips characters that are easy to misread (0/O, 1/I/L)
+const resumeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+// ResumeCodeLength is long enough that guessing someone else's code is
+// hopeless, about 49 bits
+const ResumeCodeLength = 10
+
+// NewResumeCode generates a code visitors without a public key can use to
+// get back to their draft
+func NewResumeCode() string {
+	// Bytes past the last whole multiple of the alphabet are thrown away,
+	// a plain modulo would make the first letters likelier
+	limit := 256 - 256%len(resumeAlphabet)
+
+	code := make([]byte, 0, ResumeCodeLength)
+	buf := make([]byte, ResumeCodeLength)
+	for len(code) < ResumeCodeLength {
+		rand.Read(buf) // never fails, it crashes the program instead
+		for _, b := range buf {
+			if int(b) < limit && len(code) < ResumeCodeLength {
+				code = append(code, resumeAlphabet[int(b)%len(resumeAlphabet)])
+			}
+		}
+	}
+	return string(code)
+}
+
+// NormalizeResumeCode makes typed codes case and whitespace insensitive
+func NormalizeResumeCode(code string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(code), ""))
+}
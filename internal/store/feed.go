@@ -0,0 +1,63 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/pcstyle/ssh-server/internal/api"
+)
+
+// CachedFeed is the last successfully fetched copy of a feed
+type CachedFeed struct {
+	URL       string    `json:"url"`
+	Feed      api.Feed  `json:"feed"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// FeedCache keeps fetched feeds on disk, so the blog still works when the
+// site is down or the server is offline
+type FeedCache struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFeedCache opens (or creates) the feed cache in dir
+func NewFeedCache(dir string) (*FeedCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FeedCache{dir: dir}, nil
+}
+
+// Save stores feed as the latest copy of url
+func (c *FeedCache) Save(url string, feed api.Feed) error {
+	if c == nil || url == "" {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return saveJSON(keyFile(c.dir, url), CachedFeed{URL: url, Feed: feed, FetchedAt: time.Now()})
+}
+
+// Load returns the cached copy of url, however old it is
+func (c *FeedCache) Load(url string) (CachedFeed, bool) {
+	if c == nil || url == "" {
+		return CachedFeed{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var cached CachedFeed
+	if err := loadJSON(keyFile(c.dir, url), &cached); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Warn("Failed to load cached feed", "error", err)
+		}
+		return CachedFeed{}, false
+	}
+	return cached, true
+}
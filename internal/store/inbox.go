@@ -0,0 +1,263 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// ErrThreadNotFound is returned when a reply targets an unknown reference
+var ErrThreadNotFound = errors.New("thread not found")
+
+// ErrReferenceTaken is returned when starting a thread under a reference
+// another thread already has
+var ErrReferenceTaken = errors.New("reference already in use")
+
+// Message authors
+const (
+	FromVisitor = "visitor"
+	FromOwner   = "owner"
+)
+
+// Message is a single entry in a conversation
+type Message struct {
+	From string    `json:"from"`
+	Body string    `json:"body"`
+	At   time.Time `json:"at"`
+}
+
+// Thread is one conversation, started by a contact form submission
+type Thread struct {
+	Reference string    `json:"reference"`
+	Subject   string    `json:"subject"`
+	Messages  []Message `json:"messages"`
+	Unread    int       `json:"unread"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AwaitingReply reports whether the visitor spoke last
+func (t Thread) AwaitingReply() bool {
+	return len(t.Messages) > 0 && t.Messages[len(t.Messages)-1].From == FromVisitor
+}
+
+// mailbox is everything stored for one public key
+type mailbox struct {
+	Fingerprint string   `json:"fingerprint"`
+	Threads     []Thread `json:"threads"`
+}
+
+// Inbox stores conversations on disk, keyed by public key fingerprint
+type Inbox struct {
+	dir string
+	mu  sync.Mutex
+	// refs maps a thread reference to the fingerprint that owns it
+	refs map[string]string
+}
+
+// NewInbox opens (or creates) the inbox store in dir
+func NewInbox(dir string) (*Inbox, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	in := &Inbox{dir: dir, refs: make(map[string]string)}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range files {
+		var box mailbox
+		if err := loadJSON(path, &box); err != nil {
+			log.Warn("Skipping unreadable mailbox", "file", path, "error", err)
+			continue
+		}
+		for _, t := range box.Threads {
+			in.refs[t.Reference] = box.Fingerprint
+		}
+	}
+
+	return in, nil
+}
+
+// Start records a new thread for fingerprint, from the visitor's first message
+func (in *Inbox) Start(fingerprint, reference, subject, body string) error {
+	if in == nil || fingerprint == "" || reference == "" {
+		return nil
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	// Replies are routed by reference, a clash would hand this thread to
+	// whoever had it first
+	if _, ok := in.refs[reference]; ok {
+		return ErrReferenceTaken
+	}
+
+	box := in.load(fingerprint)
+	now := time.Now()
+	box.Threads = append(box.Threads, Thread{
+		Reference: reference,
+		Subject:   subject,
+		Messages:  []Message{{From: FromVisitor, Body: body, At: now}},
+		UpdatedAt: now,
+	})
+
+	if err := in.save(box); err != nil {
+		return err
+	}
+	in.refs[reference] = fingerprint
+	return nil
+}
+
+// Taken reports whether a thread already uses reference
+func (in *Inbox) Taken(reference string) bool {
+	if in == nil {
+		return false
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	_, ok := in.refs[reference]
+	return ok
+}
+
+// Reply adds an owner reply to the thread with the given reference
+func (in *Inbox) Reply(reference, body string) error {
+	return in.append(reference, FromOwner, body)
+}
+
+// FollowUp adds another visitor message to an existing thread
+func (in *Inbox) FollowUp(reference, body string) error {
+	return in.append(reference, FromVisitor, body)
+}
+
+func (in *Inbox) append(reference, from, body string) error {
+	if in == nil {
+		return ErrThreadNotFound
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	fingerprint, ok := in.refs[reference]
+	if !ok {
+		return ErrThreadNotFound
+	}
+
+	box := in.load(fingerprint)
+	for i := range box.Threads {
+		t := &box.Threads[i]
+		if t.Reference != reference {
+			continue
+		}
+
+		now := time.Now()
+		t.Messages = append(t.Messages, Message{From: from, Body: body, At: now})
+		t.UpdatedAt = now
+		if from == FromOwner {
+			t.Unread++
+		}
+		return in.save(box)
+	}
+
+	return ErrThreadNotFound
+}
+
+// Threads lists a visitor's conversations, most recently updated first
+func (in *Inbox) Threads(fingerprint string) []Thread {
+	if in == nil || fingerprint == "" {
+		return nil
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	threads := in.load(fingerprint).Threads
+	sort.Slice(threads, func(i, j int) bool {
+		return threads[i].UpdatedAt.After(threads[j].UpdatedAt)
+	})
+	return threads
+}
+
+// Unread counts owner replies the visitor hasn't opened yet
+func (in *Inbox) Unread(fingerprint string) int {
+	total := 0
+	for _, t := range in.Threads(fingerprint) {
+		total += t.Unread
+	}
+	return total
+}
+
+// MarkRead clears the unread counter of one thread
+func (in *Inbox) MarkRead(fingerprint, reference string) error {
+	if in == nil || fingerprint == "" {
+		return nil
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	box := in.load(fingerprint)
+	for i := range box.Threads {
+		if box.Threads[i].Reference == reference && box.Threads[i].Unread > 0 {
+			box.Threads[i].Unread = 0
+			return in.save(box)
+		}
+	}
+	return nil
+}
+
+// Pending lists every thread still waiting for an owner reply, oldest first
+func (in *Inbox) Pending() []Thread {
+	if in == nil {
+		return nil
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	seen := make(map[string]bool)
+	var pending []Thread
+	for _, fingerprint := range in.refs {
+		if seen[fingerprint] {
+			continue
+		}
+		seen[fingerprint] = true
+
+		for _, t := range in.load(fingerprint).Threads {
+			if t.AwaitingReply() {
+				pending = append(pending, t)
+			}
+		}
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].UpdatedAt.Before(pending[j].UpdatedAt)
+	})
+	return pending
+}
+
+// load reads a mailbox; callers hold the lock
+func (in *Inbox) load(fingerprint string) mailbox {
+	box := mailbox{Fingerprint: fingerprint}
+	if err := loadJSON(keyFile(in.dir, fingerprint), &box); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Warn("Failed to load mailbox", "error", err)
+	}
+	return box
+}
+
+// save writes a mailbox; callers hold the lock
+func (in *Inbox) save(box mailbox) error {
+	if err := saveJSON(keyFile(in.dir, box.Fingerprint), box); err != nil {
+		return fmt.Errorf("failed to save mailbox: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrImplausible is returned for scores no real game could have produced
+var ErrImplausible = errors.New("implausible score")
+
+// Score is one finished game on the leaderboard
+type Score struct {
+	Game string `json:"game"`
+	// Name is the initials the player typed, Fingerprint their public key
+	// (empty for password logins)
+	Name        string    `json:"name"`
+	Fingerprint string    `json:"fingerprint,omitempty"`
+	Points      int       `json:"points"`
+	At          time.Time `json:"at"`
+
+	// Duration and Ticks are how long the game ran, in time and in game
+	// steps; they're what the plausibility checks go on
+	Duration time.Duration `json:"duration"`
+	Ticks    int           `json:"ticks"`
+
+	// Settings the game was played with, like the board size
+	Settings map[string]string `json:"settings,omitempty"`
+
+	// Replay is the game's replay code, for games that can be replayed
+	Replay string `json:"replay,omitempty"`
+}
+
+// Rules bound what a real game can score. Anything outside them is turned
+// away by Submit.
+type Rules struct {
+	// MinTick is the fastest the game ever steps
+	MinTick time.Duration
+	// MaxPerTick is the most points a single step can earn
+	MaxPerTick int
+	// Step divides every score, e.g. 10 when points come in tens
+	Step int
+	// Replay, when set, plays a score's replay back and reports the points
+	// and ticks the game really had. Scores without a replay, or with one
+	// that tells a different story, are turned away.
+	Replay func(code string) (points, ticks int, err error)
+}
+
+// check reports why s breaks the rules, nil when it's plausible
+func (r Rules) check(s Score) error {
+	switch {
+	case s.Points < 0 || s.Ticks < 0 || s.Duration < 0:
+		return fmt.Errorf("%w: negative values", ErrImplausible)
+	case r.Step > 1 && s.Points%r.Step != 0:
+		return fmt.Errorf("%w: %d points isn't a multiple of %d", ErrImplausible, s.Points, r.Step)
+	case s.Points > s.Ticks*r.MaxPerTick:
+		return fmt.Errorf("%w: %d points in %d ticks", ErrImplausible, s.Points, s.Ticks)
+	case s.Duration < time.Duration(s.Ticks)*r.MinTick:
+		return fmt.Errorf("%w: %d ticks in %s", ErrImplausible, s.Ticks, s.Duration)
+	case r.Replay == nil:
+		return nil
+	case s.Replay == "":
+		return fmt.Errorf("%w: no replay", ErrImplausible)
+	}
+
+	points, ticks, err := r.Replay(s.Replay)
+	switch {
+	case err != nil:
+		return fmt.Errorf("%w: replay: %v", ErrImplausible, err)
+	case points != s.Points || ticks != s.Ticks:
+		return fmt.Errorf("%w: the replay scores %d in %d ticks", ErrImplausible, points, ticks)
+	}
+	return nil
+}
+
+const (
+	// keepTop is how many scores per game are kept for the all-time board
+	keepTop = 100
+	// keepRecent is how far back every score is kept, for the weekly and
+	// daily boards
+	keepRecent = 8 * 24 * time.Hour
+)
+
+// Leaderboard keeps the arcade's scores in one JSON file
+type Leaderboard struct {
+	path   string
+	rules  map[string]Rules
+	mu     sync.Mutex
+	scores []Score
+}
+
+// NewLeaderboard opens (or creates) the leaderboard at path. Only games
+// with rules are accepted.
+func NewLeaderboard(path string, rules map[string]Rules) (*Leaderboard, error) {
+	b := &Leaderboard{path: path, rules: rules}
+	if err := loadJSON(path, &b.scores); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Submit checks s against its game's rules and records it
+func (b *Leaderboard) Submit(s Score) error {
+	if b == nil {
+		return errors.New("no leaderboard")
+	}
+	rules, ok := b.rules[s.Game]
+	if !ok {
+		return fmt.Errorf("%w: unknown game %q", ErrImplausible, s.Game)
+	}
+	if err := rules.check(s); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	scores := prune(append(append([]Score(nil), b.scores...), s), s.At)
+	if err := saveJSON(b.path, scores); err != nil {
+		return err
+	}
+	b.scores = scores
+	return nil
+}
+
+// Top returns the best n scores of game played at or after since, highest
+// first; ties go to whoever got there first
+func (b *Leaderboard) Top(game string, since time.Time, n int) []Score {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var top []Score
+	for _, s := range b.scores {
+		if s.Game == game && !s.At.Before(since) {
+			top = append(top, s)
+		}
+	}
+	rank(top)
+	if len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
+
+// Qualifies reports whether points would make the top n of game since
+// then, which is when a player gets to enter their initials
+func (b *Leaderboard) Qualifies(game string, points int, since time.Time, n int) bool {
+	if b == nil || points <= 0 {
+		return false
+	}
+	top := b.Top(game, since, n)
+	return len(top) < n || points > top[len(top)-1].Points
+}
+
+// rank sorts scores best first
+func rank(scores []Score) {
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Points != scores[j].Points {
+			return scores[i].Points > scores[j].Points
+		}
+		return scores[i].At.Before(scores[j].At)
+	})
+}
+
+// prune keeps each game's best scores and everything recent, oldest first
+func prune(scores []Score, now time.Time) []Score {
+	rank(scores)
+	kept := make([]Score, 0, len(scores))
+	perGame := make(map[string]int)
+	for _, s := range scores {
+		perGame[s.Game]++
+		if perGame[s.Game] <= keepTop || now.Sub(s.At) < keepRecent {
+			kept = append(kept, s)
+		}
+	}
+	sort.SliceStable(kept, func(i, j int) bool { return kept[i].At.Before(kept[j].At) })
+	return kept
+}
@@ -3,117 +3,215 @@ package ui
 import (
 	"strings"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/pcstyle/ssh-server/internal/api"
 )
 
-// View represents different screens in the app
-type View int
-
-const (
-	ViewHome View = iota
-	ViewContact
-	ViewAbout
-	ViewArcade
-	ViewSecrets
-	ViewExit
-)
-
-// Model is the main application model
+// Model is the main application model. Screens come from the registry
+// (see RegisterScreen), so adding one doesn't touch this file.
 type Model struct {
 	currentView  View
-	homeModel    HomeModel
-	contactModel ContactModel
-	arcadeModel  ArcadeModel
-	secretsModel SecretsModel
+	screens      map[View]Screen
+	dir          *Directory
+	paletteModel PaletteModel
+	pager        viewport.Model
+	zones        *zoneMap
+	frame        *lastFrame
+	history      history
+	session      Session
 	width        int
 	height       int
 	quitting     bool
+	toast        string
+	toastID      int
+	showHelp     bool
+	showPalette  bool
 	renderer     *lipgloss.Renderer
+	tr           *Translator
+	theme        *Theme
 }
 
 // NewModel creates a new application model
-func NewModel(apiBaseURL string, renderer *lipgloss.Renderer) Model {
+func NewModel(apiBaseURL string, renderer *lipgloss.Renderer, session Session) Model {
 	apiClient := api.NewClient(apiBaseURL)
+	if session.Clock == nil {
+		session.Clock = SystemClock{}
+	}
+	if session.Seeds == nil {
+		session.Seeds = SystemSeeds{}
+	}
+
+	// One translator and theme per session, shared by every view so
+	// toggling the language or cycling themes is instant
+	tr := NewTranslator(DetectLocale(session.Env))
+	theme := NewTheme(renderer, append(append([]Palette{}, BuiltinPalettes...), session.Themes...), session.Caps)
 
-	m := Model{
+	env := ScreenEnv{API: apiClient, Session: session, Tr: tr, Theme: theme}
+	built := buildScreens(env)
+	dir := newDirectory(built)
+
+	// Home isn't registered, it's the menu of everything that is
+	screens := map[View]Screen{ViewHome: NewHomeModel(dir, session, tr, theme)}
+	for _, s := range built {
+		screens[s.ID()] = s
+	}
+
+	return Model{
 		currentView:  ViewHome,
-		homeModel:    NewHomeModel(),
-		contactModel: NewContactModel(apiClient),
-		arcadeModel:  NewArcadeModel(),
-		secretsModel: NewSecretsModel(),
+		screens:      screens,
+		dir:          dir,
+		paletteModel: NewPaletteModel(tr, theme),
+		pager:        newPager(),
+		zones:        newZoneMap(),
+		frame:        &lastFrame{},
+		session:      session,
 		renderer:     renderer,
+		tr:           tr,
+		theme:        theme,
 	}
-
-	return m
 }
 
-// Init initializes the model
+// Init initializes the model and every screen
 func (m Model) Init() tea.Cmd {
-	return nil
+	cmds := make([]tea.Cmd, 0, len(m.screens))
+	for _, s := range m.screens {
+		cmds = append(cmds, s.Init())
+	}
+	return tea.Batch(cmds...)
+}
+
+// homeModel is the home screen, which is always there
+func (m Model) homeModel() HomeModel {
+	return m.screens[ViewHome].(HomeModel)
+}
+
+// current is the screen being shown
+func (m Model) current() Screen {
+	return m.screens[m.currentView]
 }
 
 // Update handles messages
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q":
-			if m.currentView == ViewHome {
-				m.quitting = true
-				return m, tea.Quit
-			}
+		switch {
+		case key.Matches(msg, globalKeys.Quit):
+			m.quitting = true
+			return m, tea.Quit
+		case m.showHelp:
+			// Any other key closes the overlay
+			m.showHelp = false
+			return m, nil
+		case m.showPalette:
+			var cmd tea.Cmd
+			m.paletteModel, cmd = m.paletteModel.Update(msg)
+			return m, cmd
+		case key.Matches(msg, globalKeys.Help) && !m.typing():
+			m.showHelp = true
+			m.pager.GotoTop()
+			return m, nil
+		case key.Matches(msg, globalKeys.Palette) && (msg.Type == tea.KeyCtrlK || !m.typing()):
+			// : is text while typing, ctrl+k isn't
+			m.showPalette = true
+			cmd := m.paletteModel.Open(m.paletteItems())
+			return m, cmd
+		case key.Matches(msg, globalKeys.Backward) && !m.typing():
+			// alt+arrows jump words in text fields
+			m.pager.GotoTop()
+			cmd, _ := m.goBack()
+			return m, cmd
+		case key.Matches(msg, globalKeys.Forward) && !m.typing():
+			m.pager.GotoTop()
+			cmd, _ := m.goForward()
+			return m, cmd
+		}
+		if m.scroll(msg) {
+			return m, nil
 		}
 
 	case tea.WindowSizeMsg:
+		// Every view lays itself out for the new size, not just the visible
+		// one, in the room left under the breadcrumb bar
 		m.width = msg.Width
 		m.height = msg.Height
+		m.resizePager()
+		msg.Height = max(msg.Height-breadcrumbHeight, 0)
+		for id, s := range m.screens {
+			m.screens[id], _ = s.Update(msg)
+		}
+		m.paletteModel, _ = m.paletteModel.Update(msg)
+		return m, nil
+
+	case tea.MouseMsg:
+		cmd := m.mouse(msg)
+		return m, cmd
+
+	case feedLoadedMsg:
+		// The fetch may finish after the visitor has left the blog
+		blog, ok := m.screens[ViewBlog]
+		if !ok {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.screens[ViewBlog], cmd = blog.Update(msg)
+		return m, cmd
 
 	case NavigateMsg:
-		target := msg.Target
-		switch target {
-		case ViewExit:
-			m.quitting = true
-			return m, tea.Quit
-		case ViewArcade:
-			m.currentView = ViewArcade
-			return m, m.arcadeModel.Enter()
-		case ViewSecrets:
-			m.currentView = ViewSecrets
-			return m, m.secretsModel.Enter()
-		default:
-			m.currentView = target
+		m.pager.GotoTop()
+		m.showHelp = false
+		m.showPalette = false
+		cmd := m.navigate(msg)
+		return m, cmd
+
+	case paletteClosedMsg:
+		m.showPalette = false
+		if msg.run == nil {
+			return m, nil
 		}
+		run := msg.run
+		return m, func() tea.Msg { return run }
+
+	case themeMsg:
+		m.theme.Use(msg.name)
 		return m, nil
 
-	case BackMsg:
-		// Handle back navigation
-		m.currentView = ViewHome
+	case CopyMsg:
+		cmd := m.copyCmd(msg.Text)
+		return m, cmd
+
+	case toastExpiredMsg:
+		if msg.id == m.toastID {
+			m.toast = ""
+		}
 		return m, nil
+
+	case BackMsg:
+		// Back goes where the visitor came from, home when that's unknown
+		m.pager.GotoTop()
+		m.showHelp = false
+		m.showPalette = false
+		cmd, ok := m.goBack()
+		if !ok && m.currentView != ViewHome {
+			cmd = m.revisit(ViewHome)
+		}
+		return m, cmd
 	}
 
-	// Route updates to the appropriate view
+	// Route updates to the current view
 	var cmd tea.Cmd
-	switch m.currentView {
-	case ViewHome:
-		m.homeModel, cmd = m.homeModel.Update(msg)
-	case ViewContact:
-		m.contactModel, cmd = m.contactModel.Update(msg)
-	case ViewAbout:
-		// Handle about view
-		if key, ok := msg.(tea.KeyMsg); ok {
-			if key.String() == "esc" || key.String() == "enter" {
-				m.currentView = ViewHome
-			}
-		}
-	case ViewArcade:
-		m.arcadeModel, cmd = m.arcadeModel.Update(msg)
-	case ViewSecrets:
-		m.secretsModel, cmd = m.secretsModel.Update(msg)
-	case ViewExit:
-		m.quitting = true
-		return m, tea.Quit
+	if s, ok := m.screens[m.currentView]; ok {
+		m.screens[m.currentView], cmd = s.Update(msg)
+	}
+
+	// The palette's cursor blinks on top of whatever the view is doing
+	if m.showPalette {
+		var paletteCmd tea.Cmd
+		m.paletteModel, paletteCmd = m.paletteModel.Update(msg)
+		cmd = tea.Batch(cmd, paletteCmd)
 	}
 
 	if m.quitting {
@@ -123,127 +221,102 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-// View renders the current view
-func (m Model) View() string {
-	if m.quitting {
-		return GoodbyeView()
-	}
-
-	switch m.currentView {
-	case ViewHome:
-		return m.homeModel.View()
-	case ViewContact:
-		return m.contactModel.View()
-	case ViewAbout:
-		return AboutView()
-	case ViewArcade:
-		return m.arcadeModel.View()
-	case ViewSecrets:
-		return m.secretsModel.View()
-	default:
-		return ""
+// mouse routes a mouse event. The wheel scrolls the window when the view
+// is taller than it, otherwise it goes to the view; clicks and pointer
+// moves go as a zoneMsg naming what's under the pointer.
+func (m *Model) mouse(msg tea.MouseMsg) tea.Cmd {
+	if msg.Action == tea.MouseActionPress && !m.typing() {
+		// The side buttons some mice have, like alt+arrows
+		switch msg.Button {
+		case tea.MouseButtonBackward:
+			m.pager.GotoTop()
+			cmd, _ := m.goBack()
+			return cmd
+		case tea.MouseButtonForward:
+			m.pager.GotoTop()
+			cmd, _ := m.goForward()
+			return cmd
+		}
+	}
+	if m.scroll(msg) {
+		return nil
 	}
-}
-
-// AboutView renders the about page
-func AboutView() string {
-	var b strings.Builder
 
-	// Title
-	b.WriteString(TitleStyle.Render("About pcstyle.dev"))
-	b.WriteString("\n\n")
+	var routed tea.Msg = msg
+	if _, ok := wheel(msg); !ok {
+		name, x, y := m.zones.at(msg.X, msg.Y)
+		routed = zoneMsg{MouseMsg: msg, zone: name, x: x, y: y}
+	}
 
-	// Name section
-	b.WriteString(NavItemSelectedStyle.Render("Adam Krupa (@pcstyle)"))
-	b.WriteString("\n")
-	b.WriteString(HelpStyle.Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"))
-	b.WriteString("\n\n")
+	var cmd tea.Cmd
+	switch {
+	case m.showHelp:
+		// A click closes the overlay, like any key
+		if z, ok := routed.(zoneMsg); ok && z.clicked() {
+			m.showHelp = false
+		}
+	case m.showPalette:
+		m.paletteModel, cmd = m.paletteModel.Update(routed)
+	default:
+		if s, ok := m.screens[m.currentView]; ok {
+			m.screens[m.currentView], cmd = s.Update(routed)
+		}
+	}
+	return cmd
+}
 
-	// WHO section
-	b.WriteString(LabelStyle.Render("WHO"))
-	b.WriteString("\n")
-	b.WriteString(NavItemStyle.Render("18 years old • Częstochowa, Poland"))
-	b.WriteString("\n")
-	b.WriteString(NavItemStyle.Render("AI Student @ Politechnika Częstochowska"))
-	b.WriteString("\n\n")
+// View renders the current view, folded to ASCII for terminals that need
+// it. Mouse zones are taken out here, once everything is in place.
+func (m Model) View() string {
+	content := m.withToast(m.view())
+	m.frame.record(content)
+	return m.zones.scan(m.theme.Fold(m.layout(content)))
+}
 
-	// WHAT section
-	b.WriteString(LabelStyle.Render("WHAT"))
-	b.WriteString("\n")
-	b.WriteString(NavItemStyle.Render("Blending AI, design, and creative coding. Focused on neo-brutalist"))
-	b.WriteString("\n")
-	b.WriteString(NavItemStyle.Render("design aesthetics combined with interactive and generative technologies."))
-	b.WriteString("\n\n")
+func (m Model) view() string {
+	if m.quitting {
+		return GoodbyeView(m.tr, m.theme)
+	}
+	if m.showHelp {
+		return m.helpView()
+	}
+	if m.showPalette {
+		return m.paletteModel.View()
+	}
 
-	// SKILLS section
-	b.WriteString(LabelStyle.Render("SKILLS"))
-	b.WriteString("\n")
-	b.WriteString(NavItemStyle.Render("• Frontend: Next.js 16, React 19, TypeScript, Tailwind v4, Framer Motion"))
-	b.WriteString("\n")
-	b.WriteString(NavItemStyle.Render("• Graphics: WebGL shaders, generative art"))
-	b.WriteString("\n")
-	b.WriteString(NavItemStyle.Render("• AI/Backend: Python, custom generative pipelines"))
-	b.WriteString("\n")
-	b.WriteString(NavItemStyle.Render("• Areas: AI, ML, Creative Coding, Interactive Design"))
-	b.WriteString("\n\n")
+	if s, ok := m.screens[m.currentView]; ok {
+		return s.View()
+	}
+	return ""
+}
 
-	// PROJECTS section
-	b.WriteString(LabelStyle.Render("PROJECTS"))
-	b.WriteString("\n")
-	b.WriteString(NavItemStyle.Render("• Clock Gallery - Interactive animated art (clock.pcstyle.dev)"))
-	b.WriteString("\n")
-	b.WriteString(NavItemStyle.Render("• AimDrift - Precision aim trainer (driftfield.pcstyle.dev)"))
-	b.WriteString("\n")
-	b.WriteString(NavItemStyle.Render("• PoliCalc - Grade calculator (kalkulator.pcstyle.dev)"))
-	b.WriteString("\n")
-	b.WriteString(NavItemStyle.Render("• PixelForge - AI-powered image editor (pixlab.pcstyle.dev)"))
-	b.WriteString("\n\n")
+// keyMap is the current view's keymap, for the ? overlay
+func (m Model) keyMap() help.KeyMap {
+	if s, ok := m.screens[m.currentView]; ok {
+		return s.KeyMap()
+	}
+	return m.homeModel().KeyMap()
+}
 
-	// EXPLORING section
-	b.WriteString(LabelStyle.Render("EXPLORING"))
-	b.WriteString("\n")
-	b.WriteString(NavItemStyle.Render("• Realtime AI workflow agents for animations"))
-	b.WriteString("\n")
-	b.WriteString(NavItemStyle.Render("• Neo-brutalist design system tokenization"))
-	b.WriteString("\n")
-	b.WriteString(NavItemStyle.Render("• Interactive SSH contact UX with WebRTC fallback"))
-	b.WriteString("\n\n")
+// typing reports whether the current view is taking text, where ? is
+// just a character
+func (m Model) typing() bool {
+	t, ok := m.current().(typer)
+	return ok && t.typing()
+}
 
-	// CONNECT section
-	b.WriteString(LabelStyle.Render("CONNECT"))
-	b.WriteString("\n")
-	b.WriteString(NavItemStyle.Render("GitHub: github.com/pcstyle"))
-	b.WriteString("\n")
-	b.WriteString(NavItemStyle.Render("Twitter: @pcstyle"))
-	b.WriteString("\n")
-	b.WriteString(NavItemStyle.Render("Email: adamkrupa@tuta.io"))
-	b.WriteString("\n")
-	b.WriteString(NavItemStyle.Render("Calendar: cal.com/pcstyle"))
+// helpView lists every key of the current view, plus the global ones
+func (m Model) helpView() string {
+	var b strings.Builder
+	b.WriteString(m.theme.Title.Render(m.tr.T("keys.title")))
 	b.WriteString("\n\n")
-
-	// Footer
-	b.WriteString(HelpStyle.Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"))
-	b.WriteString("\n")
-	b.WriteString(HelpStyle.Render("Built with Go + Charm (Wish, Bubble Tea, Lip Gloss)"))
+	b.WriteString(fullKeyHelp(m.theme, m.tr, m.width, m.keyMap()))
 	b.WriteString("\n")
-	b.WriteString(HelpStyle.Render("Source: github.com/pc-style/pcstyledev-ssh"))
-	b.WriteString("\n\n")
-	b.WriteString(HelpStyle.Render("Press Enter or Esc to go back"))
-
-	return BoxStyle.Render(b.String())
+	b.WriteString(m.theme.RenderHelp(m.width, m.tr.T("keys.close")))
+	return m.theme.BoxFor(m.width).Render(b.String())
 }
 
 // GoodbyeView renders the goodbye message
-func GoodbyeView() string {
-	goodbye := `
-  _____ _                 _                       _
- |_   _| |__   __ _ _ __ | | __  _   _  ___  _  _| |
-   | | | '_ \ / _' | '_ \| |/ / | | | |/ _ \| || | |
-   | | | | | | (_| | | | |   <  | |_| | (_) | || |_|
-   |_| |_| |_|\__,_|_| |_|_|\_\  \__, |\___/ \_,_(_)
-                                 |___/
-
-Thanks for visiting pcstyle.dev via SSH!
-`
-	return TitleStyle.Render(goodbye) + "\n"
+func GoodbyeView(tr *Translator, th *Theme) string {
+	return th.Title.Render(tr.T("goodbye.banner")+"\n"+tr.T("goodbye.thanks")+"\n") + "\n"
 }
@@ -6,7 +6,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pcstyle/ssh-server/internal/arena"
+	"github.com/pcstyle/ssh-server/internal/store"
 )
 
 type arcadeState int
@@ -15,6 +20,13 @@ const (
 	arcadeStateMenu arcadeState = iota
 	arcadeStateSnake
 	arcadeStateScreensaver
+	arcadeStateScores
+	arcadeStateInitials
+	arcadeStateSnakeSetup
+	arcadeStateReplay
+	arcadeStateReplayLoad
+	arcadeStateLobby
+	arcadeStateArena
 )
 
 // ArcadeModel ogarnia hidden arcade, lowkey chaos
@@ -25,53 +37,222 @@ type ArcadeModel struct {
 	snake        *snakeGame
 	statusLine   string
 	lastBootPing time.Time
+	crtSeed      int64
+	crtStarted   time.Time
+	snakeStarted time.Time
+	snakeMode    snakeMode
+	setupCursor  int
+	best         map[string]int
+	playback     *snakePlayback
+	replayBack   arcadeState
+	replayInput  textinput.Model
+	shareCode    string
+	initials     initialsPrompt
+	board        scoreBoard
+	arena        *arena.Hub
+	done         <-chan struct{}
+	lobby        arenaLobby
+	seat         *arena.Seat
+	room         arena.Snapshot
 	width        int
 	height       int
+	clock        Clock
+	seeds        Seeds
+	scores       *store.Leaderboard
+	fingerprint  string
+	tr           *Translator
+	theme        *Theme
 }
 
+// arcadeEntry trzyma klucze z katalogu, nie gotowe stringi.
+// id to nazwa gry z NavigateMsg.Game
 type arcadeEntry struct {
+	id          string
 	title       string
 	description string
 	state       arcadeState
 }
 
-// newArcadeMenu zwraca entries, bo czemu nie
-func newArcadeMenu() []arcadeEntry {
-	return []arcadeEntry{
+// newArcadeMenu zwraca entries, bo czemu nie. Multiplayer tylko jak
+// serwer ma hub z pokojami
+func newArcadeMenu(multiplayer bool) []arcadeEntry {
+	menu := []arcadeEntry{
 		{
-			title:       "SNAKE.exe",
-			description: "classic borderline laggy snake",
+			id:          "snake",
+			title:       "arcade.snake.title",
+			description: "arcade.snake.desc",
 			state:       arcadeStateSnake,
 		},
+	}
+	if multiplayer {
+		menu = append(menu, arcadeEntry{
+			id:          "versus",
+			title:       "arcade.arena.title",
+			description: "arcade.arena.desc",
+			state:       arcadeStateLobby,
+		})
+	}
+	return append(menu, []arcadeEntry{
 		{
-			title:       "CRT DREAM",
-			description: "just viby screensaver, bez kontroli",
+			id:          "crt",
+			title:       "arcade.crt.title",
+			description: "arcade.crt.desc",
 			state:       arcadeStateScreensaver,
 		},
-	}
+		{
+			id:          "replay",
+			title:       "arcade.replay.title",
+			description: "arcade.replay.desc",
+			state:       arcadeStateReplayLoad,
+		},
+		{
+			id:          "scores",
+			title:       "arcade.scores.title",
+			description: "arcade.scores.desc",
+			state:       arcadeStateScores,
+		},
+	}...)
 }
 
-// NewArcadeModel odpala arcade view, jak stary emulator
-func NewArcadeModel() ArcadeModel {
+func init() {
+	RegisterScreen("arcade", 90, func(env ScreenEnv) []Screen {
+		return []Screen{NewArcadeModel(env.Session, env.Tr, env.Theme)}
+	})
+}
+
+// NewArcadeModel odpala arcade view, jak stary emulator. Czas i seedy
+// gier idą z session.Clock i Seeds, więc test albo replay dostaje tę samą
+// grę, a wyniki lecą do session.Scores
+func NewArcadeModel(session Session, tr *Translator, theme *Theme) ArcadeModel {
+	replayInput := textinput.New()
+	replayInput.Placeholder = tr.T("replay.placeholder")
+	replayInput.CharLimit = maxReplayCode
+	replayInput.Width = 60
+
 	return ArcadeModel{
-		state:      arcadeStateMenu,
-		menu:       newArcadeMenu(),
-		statusLine: "booting tiny arcade... chwila",
+		state:       arcadeStateMenu,
+		menu:        newArcadeMenu(session.Arena != nil),
+		statusLine:  "arcade.status.booting",
+		snakeMode:   defaultSnakeMode,
+		best:        make(map[string]int),
+		replayInput: replayInput,
+		clock:       session.Clock,
+		seeds:       session.Seeds,
+		scores:      session.Scores,
+		arena:       session.Arena,
+		done:        session.Done,
+		fingerprint: session.Fingerprint,
+		tr:          tr,
+		theme:       theme,
 	}
 }
 
-// Enter odpala mini boot sequence
-func (m *ArcadeModel) Enter() tea.Cmd {
+// ID arcade
+func (m ArcadeModel) ID() View {
+	return ViewArcade
+}
+
+// Title do menu
+func (m ArcadeModel) Title() string {
+	return m.tr.T("menu.arcade.title")
+}
+
+// Description też do menu
+func (m ArcadeModel) Description() string {
+	return m.tr.T("menu.arcade.desc")
+}
+
+// Hidden bo to sekret, duh
+func (m ArcadeModel) Hidden() bool {
+	return true
+}
+
+// Unlock: wpisz snake albo games na home
+func (m ArcadeModel) Unlock() []string {
+	return []string{"snake", "games"}
+}
+
+// Init nic, boot leci z Enter
+func (m ArcadeModel) Init() tea.Cmd {
+	return nil
+}
+
+// paletteItems: każda gra osobno w palecie, jak arcade już odblokowane
+func (m ArcadeModel) paletteItems() []paletteItem {
+	items := make([]paletteItem, 0, len(m.menu))
+	for _, entry := range m.menu {
+		items = append(items, paletteItem{
+			title: m.tr.T(entry.title),
+			kind:  "palette.kind.game",
+			msg:   NavigateMsg{Target: ViewArcade, Game: entry.id},
+		})
+	}
+	return items
+}
+
+// Enter odpala mini boot sequence. Powrót z historii nic nie resetuje,
+// snake po prostu jedzie dalej (chyba że stał na pauzie)
+func (m ArcadeModel) Enter(how Arrival) (Screen, tea.Cmd) {
+	if how == ArriveHistory {
+		if m.state == arcadeStateSnake && m.snake != nil && m.snake.running() {
+			return m, m.snake.init()
+		}
+		if m.state == arcadeStateReplay {
+			return m, m.playback.init()
+		}
+		if m.state == arcadeStateLobby {
+			return m, m.refreshLobby()
+		}
+		return m, nil
+	}
 	m.state = arcadeStateMenu
-	m.statusLine = "booting tiny arcade... chwila"
-	m.lastBootPing = time.Now()
-	return tea.Tick(350*time.Millisecond, func(time.Time) tea.Msg {
-		return arcadeBootMsg(time.Now())
+	m.statusLine = "arcade.status.booting"
+	m.lastBootPing = m.clock.Now()
+	ping := m.lastBootPing
+	return m, tea.Tick(350*time.Millisecond, func(time.Time) tea.Msg {
+		return arcadeBootMsg(ping)
 	})
 }
 
+// Leave mrozi snake'a: ticki w locie są już nieważne, Enter puści nowe.
+// Pokoju multiplayer nie da się zapauzować, więc z niego się wychodzi
+func (m ArcadeModel) Leave() (Screen, tea.Cmd) {
+	if m.snake != nil {
+		m.snake.gen++
+	}
+	if m.playback != nil {
+		m.playback.gen++
+	}
+	if m.seat != nil {
+		m.seat.Leave()
+		m.seat = nil
+		m.state = arcadeStateLobby
+	}
+	m.lobby.tick++
+	return m, nil
+}
+
+// crumb to odpalona gra do breadcrumbs, w menu pusto
+func (m ArcadeModel) crumb() string {
+	state := m.state
+	switch state {
+	case arcadeStateInitials, arcadeStateSnakeSetup:
+		state = arcadeStateSnake // wybór trybu i inicjały to też snake
+	case arcadeStateReplay:
+		state = arcadeStateReplayLoad
+	case arcadeStateArena:
+		state = arcadeStateLobby
+	}
+	for _, entry := range m.menu {
+		if entry.state == state && state != arcadeStateMenu {
+			return m.tr.T(entry.title)
+		}
+	}
+	return ""
+}
+
 // Update łapie eventy i wysyła dalej jak trzeba
-func (m ArcadeModel) Update(msg tea.Msg) (ArcadeModel, tea.Cmd) {
+func (m ArcadeModel) Update(msg tea.Msg) (Screen, tea.Cmd) {
 	switch typed := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = typed.Width
@@ -84,29 +265,76 @@ func (m ArcadeModel) Update(msg tea.Msg) (ArcadeModel, tea.Cmd) {
 			return m.handleMenuKey(typed)
 		case arcadeStateSnake:
 			return m.forwardToSnake(typed)
+		case arcadeStateSnakeSetup:
+			return m.handleSetupKey(typed)
+		case arcadeStateInitials:
+			return m.handleInitialsKey(typed)
+		case arcadeStateReplay:
+			return m.handleReplayKey(typed)
+		case arcadeStateReplayLoad:
+			return m.handleReplayLoadKey(typed)
+		case arcadeStateLobby:
+			return m.handleLobbyKey(typed)
+		case arcadeStateArena:
+			return m.handleArenaKey(typed)
+		case arcadeStateScores:
+			return m.handleScoresKey(typed), nil
 		case arcadeStateScreensaver:
-			if typed.String() == "esc" || typed.String() == "enter" {
-				m.statusLine = "ok screensaver off, wracamy"
+			if key.Matches(typed, crtKeys.Leave) {
+				m.statusLine = "arcade.status.crt_off"
 				m.state = arcadeStateMenu
-			} else if typed.String() == "q" {
-				return m, func() tea.Msg { return BackMsg{} }
 			}
 		}
 
+	case tea.MouseMsg:
+		// kółko chodzi po menu i lobby, w grach nic nie robi
+		if dir, ok := wheel(typed); ok {
+			switch m.state {
+			case arcadeStateMenu:
+				m.cursor = clamp(m.cursor+dir, 0, len(m.menu)-1)
+			case arcadeStateLobby:
+				m.lobby.cursor = clamp(m.lobby.cursor+dir, 0, lobbyFixed+len(m.lobby.rooms)-1)
+			}
+		}
+
+	case zoneMsg:
+		return m.handleMouse(typed)
+
+	case replayTickMsg:
+		return m.onReplayTick(typed)
+
+	case arenaLobbyTickMsg:
+		return m.onLobbyTick(typed)
+
+	case arenaMsg:
+		return m.onArenaMsg(typed)
+
 	case arcadeBootMsg:
-		if time.Since(m.lastBootPing) > 200*time.Millisecond {
-			m.statusLine = "ok arcade ready, let's go"
+		// tylko boot z ostatniego wejścia, stary się spóźnił
+		if time.Time(typed).Equal(m.lastBootPing) {
+			m.statusLine = "arcade.status.ready"
 		}
 
 	case snakeTickMsg:
-		if m.state == arcadeStateSnake && m.snake != nil {
+		if m.state == arcadeStateSnake && m.snake != nil && typed.game == m.snake && typed.gen == m.snake.gen {
 			var cmd tea.Cmd
 			m.snake, cmd = m.snake.updateTick()
-			if !m.snake.alive {
-				m.statusLine = "rip snake, press r żeby zrespawnić"
+			switch {
+			case !m.snake.alive:
+				m.gameOver()
+			case m.snake.levelDone:
+				m.statusLine = "arcade.status.level_complete"
 			}
 			return m, cmd
 		}
+
+	default:
+		// mruganie kursora i reszta dla pola z kodem
+		if m.state == arcadeStateReplayLoad {
+			var cmd tea.Cmd
+			m.replayInput, cmd = m.replayInput.Update(msg)
+			return m, cmd
+		}
 	}
 
 	return m, nil
@@ -117,139 +345,412 @@ func (m ArcadeModel) View() string {
 	switch m.state {
 	case arcadeStateSnake:
 		return m.renderSnake()
+	case arcadeStateSnakeSetup:
+		return m.renderSnakeSetup()
+	case arcadeStateReplay:
+		return m.renderReplay()
+	case arcadeStateReplayLoad:
+		return m.renderReplayLoad()
+	case arcadeStateLobby:
+		return m.renderLobby()
+	case arcadeStateArena:
+		return m.renderArena()
 	case arcadeStateScreensaver:
 		return m.renderScreensaver()
+	case arcadeStateScores:
+		return m.renderScores()
+	case arcadeStateInitials:
+		return m.renderInitials()
 	default:
 		return m.renderMenu()
 	}
 }
 
+// KeyMap zależy od tego co akurat leci: menu, gra, CRT albo wyniki
+func (m ArcadeModel) KeyMap() help.KeyMap {
+	switch m.state {
+	case arcadeStateSnake:
+		// replay jest dopiero po śmierci
+		keys := snakeKeys
+		dead := m.snake != nil && !m.snake.alive
+		keys.Watch.SetEnabled(dead)
+		keys.Share.SetEnabled(dead)
+		return keys
+	case arcadeStateReplay:
+		return replayKeys
+	case arcadeStateReplayLoad:
+		return replayLoadKeys
+	case arcadeStateSnakeSetup:
+		return snakeSetupKeys
+	case arcadeStateLobby:
+		return arenaLobbyKeys
+	case arcadeStateArena:
+		return m.arenaKeyMap()
+	case arcadeStateScreensaver:
+		return crtKeys
+	case arcadeStateScores:
+		return scoresKeys
+	case arcadeStateInitials:
+		return initialsKeys
+	default:
+		return arcadeKeys
+	}
+}
+
 func (m ArcadeModel) renderMenu() string {
 	var b strings.Builder
 
-	b.WriteString(drawArcadeBanner())
+	b.WriteString(drawArcadeBanner(m.theme.Caps.ASCII))
 	b.WriteString("\n")
-	b.WriteString(NavItemStyle.Render("mini arcade hub"))
+	b.WriteString(m.theme.NavItem.Render(m.tr.T("arcade.hub")))
 	b.WriteString("\n\n")
 
 	for i, entry := range m.menu {
 		cursor := "  "
 		if i == m.cursor {
-			cursor = NavArrowStyle.Render("→ ")
+			cursor = m.theme.NavArrow.Render(m.theme.Glyphs.Arrow + " ")
 		}
 
-		itemStyle := NavItemStyle
+		itemStyle := m.theme.NavItem
 		if i == m.cursor {
-			itemStyle = NavItemSelectedStyle
+			itemStyle = m.theme.NavItemSelected
 		}
 
-		line := fmt.Sprintf("%s%s - %s\n", cursor, itemStyle.Render(entry.title), HelpStyle.Render(entry.description))
-		b.WriteString(line)
+		title, desc := itemStyle.Render(m.tr.T(entry.title)), m.theme.Help.UnsetMarginTop().Render(m.tr.T(entry.description))
+		line := fmt.Sprintf("%s%s - %s", cursor, title, desc)
+		if compact(m.width) {
+			// za wąsko na jedną linię, opis idzie pod spód
+			line = fmt.Sprintf("%s%s\n      %s", cursor, title, desc)
+		}
+		b.WriteString(zone(fmt.Sprintf("arcade.%d", i), line))
+		b.WriteString("\n")
 	}
 
 	b.WriteString("\n")
-	b.WriteString(HelpStyle.Render("Enter to launch • esc/q żeby wrócić • r resets snake gdy padniesz"))
+	b.WriteString(keyHelp(m.theme, m.tr, m.width, m.KeyMap()))
 	if m.statusLine != "" {
 		b.WriteString("\n")
-		b.WriteString(HelpStyle.Render(m.statusLine))
+		b.WriteString(m.theme.RenderHelp(m.width, m.tr.T(m.statusLine)))
 	}
 
-	return BoxStyle.Render(b.String())
+	return m.theme.BoxFor(m.width).Render(b.String())
 }
 
 func (m ArcadeModel) renderSnake() string {
 	if m.snake == nil {
-		return BoxStyle.Render("snake not booted??? jak to w ogóle...")
+		return m.theme.Box.Render(m.tr.T("snake.not_booted"))
 	}
 
-	board := m.snake.draw()
+	board := zone("snake.board", m.snake.draw(m.theme.Glyphs))
+	hud := m.tr.T("snake.hud", m.snake.score, m.snake.level, m.bestScore(m.snake.mode))
+	if m.snake.bonusLeft > 0 {
+		hud += " " + m.theme.Glyphs.Bullet + " " + m.tr.T("snake.bonus", m.snake.bonusLeft)
+	}
 	lines := []string{
-		TitleStyle.Render("SNAKE.exe // karma dla nostalgii"),
+		m.theme.Title.Render(m.tr.T("snake.title")) + "  " + m.theme.Help.UnsetMarginTop().Render(m.snake.mode.title(m.tr)),
 		board,
-		HelpStyle.Render(fmt.Sprintf("score: %d  • steruj strzałkami / wasd • esc/q żeby wyjść • r respawn", m.snake.score)),
+		keyHelp(m.theme, m.tr, m.width, m.KeyMap(), hud),
 	}
 
-	if !m.snake.alive {
-		lines = append(lines, ErrorStyle.Render("padłeś. r = retry, esc = wyjdź"))
+	switch {
+	case !m.snake.alive:
+		lines = append(lines, m.theme.Error.Render(m.tr.T("snake.dead")))
+		if m.shareCode != "" {
+			lines = append(lines, m.renderShareCode())
+		}
+	case m.snake.levelDone:
+		lines = append(lines, m.theme.Success.Render(m.tr.T("snake.level_complete", m.snake.level)))
+	case m.snake.paused:
+		lines = append(lines, m.theme.Success.Render(m.tr.T("snake.paused")))
 	}
 
 	if m.statusLine != "" {
-		lines = append(lines, HelpStyle.Render(m.statusLine))
+		lines = append(lines, m.theme.RenderHelp(m.width, m.tr.T(m.statusLine)))
 	}
 
-	return BoxStyle.Render(strings.Join(lines, "\n\n"))
+	return m.theme.BoxFor(m.width).Render(strings.Join(lines, "\n\n"))
 }
 
 func (m ArcadeModel) renderScreensaver() string {
-	frame := drawScreensaver(time.Now())
+	cols, rows := screensaverSize(m.width, m.height)
+	// nowa klatka szumu co screensaverFrame, liczona od włączenia
+	frame := int64(m.clock.Now().Sub(m.crtStarted) / screensaverFrame)
+	noise := drawScreensaver(m.theme.Glyphs, m.crtSeed+frame, cols, rows)
 	lines := []string{
-		TitleStyle.Render("CRT DREAM // tak, kompletnie bezużyteczne"),
-		frame,
-		HelpStyle.Render("press esc lub enter, bo inaczej będziesz patrzeć na ten glitch forever"),
+		m.theme.Title.Render(m.tr.T("crt.title")),
+		noise,
+		keyHelp(m.theme, m.tr, m.width, m.KeyMap()),
 	}
 	if m.statusLine != "" {
-		lines = append(lines, HelpStyle.Render(m.statusLine))
+		lines = append(lines, m.theme.RenderHelp(m.width, m.tr.T(m.statusLine)))
+	}
+	return m.theme.BoxFor(m.width).Render(strings.Join(lines, "\n\n"))
+}
+
+// snakeBoardSize liczy planszę z miejsca w oknie: ramka boxa, tytuł, help
+// i status zjadają resztę. Bez rozmiaru zostaje klasyczne 22x12
+func snakeBoardSize(width, height int) (int, int) {
+	if width == 0 || height == 0 {
+		return 22, 12
+	}
+	return clamp(width-10, 12, 60), clamp(height-20, 6, 24)
+}
+
+// screensaverSize to samo dla CRT, tylko mniej chrome dookoła
+func screensaverSize(width, height int) (int, int) {
+	if width == 0 || height == 0 {
+		return 30, 10
+	}
+	return clamp(width-10, 16, 70), clamp(height-16, 4, 20)
+}
+
+// Play odpala grę od razu, bez menu i boot sequence, np. z palety.
+// Nieznana gra = zwykłe wejście
+func (m ArcadeModel) Play(game string) (Screen, tea.Cmd) {
+	for i, entry := range m.menu {
+		if entry.id == game {
+			m.cursor = i
+			cmd := m.launch(entry)
+			return m, cmd
+		}
 	}
-	return BoxStyle.Render(strings.Join(lines, "\n\n"))
+	return m.Enter(ArriveNew)
 }
 
-func (m ArcadeModel) handleMenuKey(key tea.KeyMsg) (ArcadeModel, tea.Cmd) {
-	switch key.String() {
-	case "up", "k":
+// launch przełącza na grę z menu
+func (m *ArcadeModel) launch(entry arcadeEntry) tea.Cmd {
+	switch entry.state {
+	case arcadeStateSnake:
+		// zapauzowana gra czeka, nowa zaczyna się od wyboru trybu
+		if m.snake != nil && m.snake.alive && m.snake.paused {
+			m.state = arcadeStateSnake
+			m.statusLine = "arcade.status.snake_waiting"
+			return nil
+		}
+		m.state = arcadeStateSnakeSetup
+		m.setupCursor = 0
+		m.statusLine = "arcade.status.snake_setup"
+	case arcadeStateScreensaver:
+		m.state = arcadeStateScreensaver
+		m.statusLine = "arcade.status.crt_on"
+		m.crtSeed = m.seeds.Seed()
+		m.crtStarted = m.clock.Now()
+	case arcadeStateReplayLoad:
+		return m.openReplayLoad()
+	case arcadeStateLobby:
+		m.lobby.cursor = 0
+		return m.openLobby()
+	case arcadeStateScores:
+		m.state = arcadeStateScores
+		m.statusLine = ""
+		m.board = scoreBoard{mode: modeIndex(m.snakeMode)}
+	}
+	return nil
+}
+
+// newSnake: świeża gra na rozmiar okna, z nowym seedem, w wybranym trybie
+func (m ArcadeModel) newSnake() *snakeGame {
+	width, height := snakeBoardSize(m.width, m.height)
+	return newSnakeGame(width, height, m.seeds.Seed(), m.snakeMode)
+}
+
+// startSnake odpala grę w trybie z pickera
+func (m *ArcadeModel) startSnake() tea.Cmd {
+	m.snake = m.newSnake()
+	m.snakeStarted = m.clock.Now()
+	m.shareCode = ""
+	m.state = arcadeStateSnake
+	m.statusLine = "arcade.status.snake_loaded"
+	return m.snake.init()
+}
+
+// bestScore to rekord trybu: z leaderboardu albo z tej sesji, jak
+// leaderboardu nie ma albo wynik się nie załapał
+func (m ArcadeModel) bestScore(mode snakeMode) int {
+	best := m.best[mode.id()]
+	if top := m.scores.Top(mode.id(), time.Time{}, 1); len(top) > 0 {
+		best = max(best, top[0].Points)
+	}
+	return best
+}
+
+func (m ArcadeModel) handleMenuKey(msg tea.KeyMsg) (ArcadeModel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, arcadeKeys.Up):
 		if m.cursor > 0 {
 			m.cursor--
 		} else {
 			m.cursor = len(m.menu) - 1
 		}
-	case "down", "j":
+	case key.Matches(msg, arcadeKeys.Down):
 		if m.cursor < len(m.menu)-1 {
 			m.cursor++
 		} else {
 			m.cursor = 0
 		}
-	case "enter", " ":
-		entry := m.menu[m.cursor]
-		switch entry.state {
-		case arcadeStateSnake:
-			m.snake = newSnakeGame()
-			m.state = arcadeStateSnake
-			m.statusLine = "snake loaded, nie crashuj w siebie pls"
-			return m, m.snake.init()
-		case arcadeStateScreensaver:
-			m.state = arcadeStateScreensaver
-			m.statusLine = "enjoy the glitch, chyba"
-		}
-	case "esc", "q":
+	case key.Matches(msg, arcadeKeys.Launch):
+		cmd := m.launch(m.menu[m.cursor])
+		return m, cmd
+	case key.Matches(msg, arcadeKeys.Back):
 		return m, func() tea.Msg { return BackMsg{} }
 	}
 	return m, nil
 }
 
-func (m ArcadeModel) forwardToSnake(key tea.KeyMsg) (ArcadeModel, tea.Cmd) {
+// respawn bierze nowy rozmiar okna, w trakcie gry plansza stoi
+func (m *ArcadeModel) respawn() tea.Cmd {
+	m.snake.resize(snakeBoardSize(m.width, m.height))
+	m.snake.reset(m.seeds.Seed())
+	m.snakeStarted = m.clock.Now()
+	m.shareCode = ""
+	m.statusLine = "arcade.status.respawned"
+	return m.snake.init()
+}
+
+// togglePause pauzuje albo wznawia, ze statusem
+func (m *ArcadeModel) togglePause() tea.Cmd {
+	cmd := m.snake.togglePause()
+	switch {
+	case !m.snake.alive || m.snake.levelDone:
+	case m.snake.paused:
+		m.statusLine = "arcade.status.paused"
+	default:
+		m.statusLine = "arcade.status.resumed"
+	}
+	return cmd
+}
+
+// nextLevel leci dalej po "level complete"
+func (m *ArcadeModel) nextLevel() tea.Cmd {
+	if !m.snake.levelDone {
+		return nil
+	}
+	cmd := m.snake.nextLevel()
+	m.statusLine = "arcade.status.next_level"
+	return cmd
+}
+
+// handleMouse: w menu najechanie podświetla a klik odpala, w snake'u klik
+// skręca w stronę kliknięcia (albo respawn jak nie żyje), CRT klik wyłącza
+func (m ArcadeModel) handleMouse(msg zoneMsg) (ArcadeModel, tea.Cmd) {
+	switch m.state {
+	case arcadeStateMenu:
+		if i, ok := zoneIndex(msg.zone, "arcade."); ok && i < len(m.menu) {
+			m.cursor = i
+			if msg.clicked() {
+				cmd := m.launch(m.menu[i])
+				return m, cmd
+			}
+		}
+	case arcadeStateLobby:
+		if i, ok := zoneIndex(msg.zone, "arena."); ok && i < lobbyFixed+len(m.lobby.rooms) {
+			m.lobby.cursor = i
+			if msg.clicked() {
+				cmd := m.joinArena()
+				return m, cmd
+			}
+		}
+	case arcadeStateReplay:
+		if msg.zone != "replay.board" || !msg.clicked() {
+			return m, nil
+		}
+		if m.playback.done() {
+			cmd := m.playback.restart()
+			return m, cmd
+		}
+		cmd := m.playback.togglePause()
+		return m, cmd
+	case arcadeStateSnakeSetup:
+		if i, ok := zoneIndex(msg.zone, "snake.setup."); ok && msg.clicked() {
+			m.setupCursor = i
+			m.changeSetup(1)
+		}
+		if msg.zone == "snake.start" && msg.clicked() {
+			cmd := m.startSnake()
+			return m, cmd
+		}
+	case arcadeStateSnake:
+		if !msg.clicked() || m.snake == nil || msg.zone != "snake.board" {
+			return m, nil
+		}
+		switch {
+		case !m.snake.alive:
+			cmd := m.respawn()
+			return m, cmd
+		case m.snake.levelDone:
+			cmd := m.nextLevel()
+			return m, cmd
+		case m.snake.paused:
+			cmd := m.togglePause()
+			return m, cmd
+		}
+		// -1 bo ramka planszy
+		m.snake.steerTowards(snakePoint{x: msg.x - 1, y: msg.y - 1})
+	case arcadeStateScreensaver:
+		if msg.clicked() {
+			m.statusLine = "arcade.status.crt_off"
+			m.state = arcadeStateMenu
+		}
+	case arcadeStateScores:
+		if msg.zone == "scores.mode" && msg.clicked() {
+			m.board.mode = (m.board.mode + 1) % len(snakeModes())
+		}
+		if i, ok := zoneIndex(msg.zone, "scores.period."); ok && msg.clicked() && i < len(scorePeriods) {
+			m.board.period = i
+		}
+	}
+	return m, nil
+}
+
+func (m ArcadeModel) forwardToSnake(msg tea.KeyMsg) (ArcadeModel, tea.Cmd) {
 	if m.snake == nil {
-		m.snake = newSnakeGame()
+		m.snake = m.newSnake()
+		m.snakeStarted = m.clock.Now()
 		cmd := m.snake.init()
 		return m, cmd
 	}
 
-	switch key.String() {
-	case "esc", "q":
+	switch {
+	case key.Matches(msg, snakeKeys.Leave):
+		// wyjście pauzuje, snake czeka w menu aż wrócisz
+		if m.snake.running() {
+			m.snake.togglePause()
+		}
 		m.state = arcadeStateMenu
-		m.statusLine = "snake paused, pewnie głodny"
+		m.statusLine = "arcade.status.snake_left"
 		return m, nil
-	case "r":
-		m.snake.reset()
-		m.statusLine = "respawned, powodzenia elo"
-		return m, m.snake.init()
+	case key.Matches(msg, snakeKeys.Respawn):
+		cmd := m.respawn()
+		return m, cmd
+	case key.Matches(msg, snakeKeys.Pause):
+		cmd := m.togglePause()
+		return m, cmd
+	case key.Matches(msg, snakeKeys.NextLevel):
+		cmd := m.nextLevel()
+		return m, cmd
+	case key.Matches(msg, snakeKeys.Watch) && !m.snake.alive:
+		cmd := m.watch(m.snake.replay(), arcadeStateSnake)
+		return m, cmd
+	case key.Matches(msg, snakeKeys.Share) && !m.snake.alive:
+		cmd := m.shareReplay()
+		return m, cmd
 	}
 
 	var cmd tea.Cmd
-	m.snake, cmd = m.snake.handleKey(key)
+	m.snake, cmd = m.snake.handleKey(msg)
 	return m, cmd
 }
 
-// drawArcadeBanner robi ascii bo czemu nie
-func drawArcadeBanner() string {
+// drawArcadeBanner robi ascii bo czemu nie. Bloki █▀▄ na starych
+// terminalach to krzaki, więc tam wersja z samych literek
+func drawArcadeBanner(ascii bool) string {
+	if ascii {
+		return `
++- ARC4D3 ---------------+
+|  >> A R C A D E <<     |
+|  >> insert coin <<     |
++------------------------+`
+	}
 	return `
 ╔═ ARC4D3 ═══════════════╗
 ║  █░█ █▀█ █▄░█ ▄▀█ ▄▀█  ║
@@ -261,9 +762,18 @@ type arcadeBootMsg time.Time
 
 // snake internals -----------------------------------------------------------
 
-type snakeTickMsg struct{}
+// snakeTickMsg jest od konkretnej gry i generacji, stare ticki (po
+// respawnie, pauzie albo wyjściu z widoku) lecą do kosza
+type snakeTickMsg struct {
+	game *snakeGame
+	gen  int
+}
 
 type snakeGame struct {
+	gen      int
+	seed     int64
+	mode     snakeMode
+	ticks    int
 	width    int
 	height   int
 	snake    []snakePoint
@@ -275,6 +785,21 @@ type snakeGame struct {
 	speed    time.Duration
 	random   *rand.Rand
 	lastMove time.Time
+
+	// level to numer poziomu, eaten ile jabłek już na nim poszło.
+	// levelDone = cel zaliczony, ticki stoją aż gracz pójdzie dalej
+	level     int
+	eaten     int
+	levelDone bool
+	paused    bool
+	obstacles map[snakePoint]bool
+
+	// bonus to jabłko za snakeBonusPoints, znika po bonusLeft tickach
+	bonus     snakePoint
+	bonusLeft int
+
+	// inputs to każdy skręt z numerem ticka, z seedem starczy na replay
+	inputs []snakeInput
 }
 
 type snakePoint struct {
@@ -282,87 +807,166 @@ type snakePoint struct {
 	y int
 }
 
-func newSnakeGame() *snakeGame {
-	seed := time.Now().UnixNano()
-	g := &snakeGame{
-		width:  22,
-		height: 12,
-		snake: []snakePoint{
-			{1, 1},
-			{0, 1},
-		},
-		dir:     snakePoint{1, 0},
-		nextDir: snakePoint{1, 0},
-		alive:   true,
-		speed:   180 * time.Millisecond,
-		random:  rand.New(rand.NewSource(seed)),
-	}
-	g.spawnApple()
+// newSnakeGame: cała losowość gry (jabłka, przeszkody, bonusy, okruszki)
+// idzie z seed, ten sam seed, tryb i te same ruchy = ta sama gra
+func newSnakeGame(width, height int, seed int64, mode snakeMode) *snakeGame {
+	g := &snakeGame{width: width, height: height, mode: mode}
+	g.reset(seed)
 	return g
 }
 
-func (g *snakeGame) reset() {
+// reset to nowa gra od zera, z nowym seedem, w tym samym trybie
+func (g *snakeGame) reset(seed int64) {
+	g.seed = seed
+	g.random = rand.New(rand.NewSource(seed))
+	g.alive = true
+	g.paused = false
+	g.score = 0
+	g.ticks = 0
+	g.level = 1
+	g.inputs = nil
+	g.gen++
+	g.startLevel()
+}
+
+// startLevel stawia snake'a na starcie, z nowym układem przeszkód i
+// prędkością poziomu
+func (g *snakeGame) startLevel() {
+	difficulty := g.mode.difficulty()
 	g.snake = []snakePoint{{1, 1}, {0, 1}}
 	g.dir = snakePoint{1, 0}
 	g.nextDir = g.dir
-	g.alive = true
-	g.score = 0
+	g.eaten = 0
+	g.levelDone = false
+	g.bonusLeft = 0
+	g.speed = max(difficulty.speed-time.Duration(g.level-1)*2*difficulty.speedUp, difficulty.minSpeed)
+	g.obstacles = g.layout()
 	g.spawnApple()
 }
 
+// nextLevel odpala kolejny poziom po "level complete"
+func (g *snakeGame) nextLevel() tea.Cmd {
+	if !g.alive || !g.levelDone {
+		return nil
+	}
+	g.advance()
+	g.gen++
+	return g.init()
+}
+
+// advance to sama zmiana levelu, replay robi ją od razu bez czekania
+func (g *snakeGame) advance() {
+	g.level++
+	g.startLevel()
+}
+
+// togglePause: pauza zabija ticki w locie, wznowienie puszcza nowe
+func (g *snakeGame) togglePause() tea.Cmd {
+	if !g.alive || g.levelDone {
+		return nil
+	}
+	g.paused = !g.paused
+	g.gen++
+	if g.paused {
+		return nil
+	}
+	return g.init()
+}
+
+// running to gra, której lecą ticki: żyje, bez pauzy, w trakcie levelu
+func (g *snakeGame) running() bool {
+	return g.alive && !g.paused && !g.levelDone
+}
+
+func (g *snakeGame) resize(width, height int) {
+	g.width = width
+	g.height = height
+}
+
 func (g *snakeGame) init() tea.Cmd {
+	gen := g.gen
 	return tea.Tick(g.speed, func(time.Time) tea.Msg {
-		return snakeTickMsg{}
+		return snakeTickMsg{game: g, gen: gen}
 	})
 }
 
-func (g *snakeGame) handleKey(key tea.KeyMsg) (*snakeGame, tea.Cmd) {
-	switch key.String() {
-	case "up", "k", "w":
+func (g *snakeGame) handleKey(msg tea.KeyMsg) (*snakeGame, tea.Cmd) {
+	if !g.running() {
+		return g, nil // na pauzie i po śmierci nie skręcamy na ślepo
+	}
+	switch {
+	case key.Matches(msg, snakeKeys.Up):
 		g.queueDir(0, -1)
-	case "down", "j", "s":
+	case key.Matches(msg, snakeKeys.Down):
 		g.queueDir(0, 1)
-	case "left", "h", "a":
+	case key.Matches(msg, snakeKeys.Left):
 		g.queueDir(-1, 0)
-	case "right", "l", "d":
+	case key.Matches(msg, snakeKeys.Right):
 		g.queueDir(1, 0)
 	}
 	return g, nil
 }
 
 func (g *snakeGame) updateTick() (*snakeGame, tea.Cmd) {
-	if !g.alive {
+	if !g.running() {
+		return g, nil
+	}
+	if !g.step() {
 		return g, nil
 	}
+	return g, g.init()
+}
 
+// step to jeden ruch gry, bez timera. Zwraca czy gra leci dalej (nie
+// umarła i nie skończyła levelu), replay kręci tym w pętli
+func (g *snakeGame) step() bool {
+	g.ticks++
 	g.dir = g.nextDir
 	head := g.nextHead()
 
-	if g.hitWall(head) || g.hitSelf(head) {
+	if g.hitWall(head) || g.hitSelf(head) || g.obstacles[head] {
 		g.alive = false
-		return g, nil
+		return false
 	}
 
 	g.snake = append([]snakePoint{head}, g.snake...)
 
-	if head == g.apple {
-		g.score += 10
-		g.spawnApple()
-		// przyspiesz trochę, bo czemu nie
-		if g.speed > 80*time.Millisecond {
-			g.speed -= 8 * time.Millisecond
+	switch {
+	case head == g.apple:
+		g.score += snakeApplePoints
+		g.eaten++
+		if g.eaten >= g.mode.difficulty().apples {
+			g.levelDone = true
+			return false
 		}
-	} else {
+		g.spawnApple()
+		g.maybeSpawnBonus()
+		// przyspiesz trochę, bo czemu nie, ale nie poniżej minSpeed
+		difficulty := g.mode.difficulty()
+		g.speed = max(g.speed-difficulty.speedUp, difficulty.minSpeed)
+	case g.bonusLeft > 0 && head == g.bonus:
+		g.score += snakeBonusPoints
+		g.bonusLeft = 0
+	default:
 		g.snake = g.snake[:len(g.snake)-1]
 	}
 
-	return g, g.init()
+	// bonus się kurczy co tick, jak nikt go nie zjadł to przepada
+	if g.bonusLeft > 0 {
+		g.bonusLeft--
+	}
+	return true
 }
 
-func (g *snakeGame) draw() string {
+func (g *snakeGame) draw(glyphs Glyphs) string {
 	var b strings.Builder
-	borderTop := "╔" + strings.Repeat("═", g.width) + "╗"
-	borderBottom := "╚" + strings.Repeat("═", g.width) + "╝"
+	// w trybie wrap ramka jest dziurawa, bo przez nią się przechodzi
+	horizontal, vertical := glyphs.Horizontal, glyphs.Vertical
+	if g.mode.wrap {
+		horizontal, vertical = glyphs.Crumb, glyphs.Crumb
+	}
+	borderTop := glyphs.TopLeft + strings.Repeat(horizontal, g.width) + glyphs.TopRight
+	borderBottom := glyphs.BottomLeft + strings.Repeat(horizontal, g.width) + glyphs.BottomRight
 	b.WriteString(borderTop + "\n")
 
 	body := make(map[snakePoint]bool)
@@ -370,42 +974,93 @@ func (g *snakeGame) draw() string {
 		body[part] = true
 	}
 
+	// okruszki migają co tick, nie co klatkę, i nie ruszają g.random,
+	// więc jabłka nie zależą od tego ile razy coś się narysowało
+	crumbs := rand.New(rand.NewSource(g.seed + int64(g.ticks)))
+
 	for y := 0; y < g.height; y++ {
-		b.WriteString("║")
+		b.WriteString(vertical)
 		for x := 0; x < g.width; x++ {
 			p := snakePoint{x: x, y: y}
 			switch {
 			case p == g.snake[0]:
-				b.WriteString("■")
-			case p == g.apple:
-				b.WriteString("●")
+				b.WriteString(glyphs.SnakeHead)
+			case p == g.apple && !g.levelDone:
+				b.WriteString(glyphs.Apple)
+			case p == g.bonus && g.bonusLeft > 0:
+				b.WriteString(glyphs.Bonus)
 			case body[p]:
-				b.WriteString("░")
+				b.WriteString(glyphs.SnakeBody)
+			case g.obstacles[p]:
+				b.WriteString(glyphs.Obstacle)
 			default:
-				if (x+y)%7 == 3 && g.random.Intn(40) == 0 {
-					b.WriteString("·")
+				if (x+y)%7 == 3 && crumbs.Intn(40) == 0 {
+					b.WriteString(glyphs.Crumb)
 				} else {
 					b.WriteString(" ")
 				}
 			}
 		}
-		b.WriteString("║\n")
+		b.WriteString(vertical + "\n")
 	}
 
 	b.WriteString(borderBottom)
 	return b.String()
 }
 
+// steerTowards skręca w stronę punktu na planszy: po dłuższej osi, a jak
+// to zawracanie albo jedziemy już tam, to po krótszej
+func (g *snakeGame) steerTowards(p snakePoint) {
+	head := g.snake[0]
+	dx, dy := p.x-head.x, p.y-head.y
+	horizontal := snakePoint{x: sign(dx)}
+	vertical := snakePoint{y: sign(dy)}
+	first, second := horizontal, vertical
+	if abs(dy) > abs(dx) {
+		first, second = vertical, horizontal
+	}
+	for _, d := range []snakePoint{first, second} {
+		if d != (snakePoint{}) && d != g.dir && (d.x != -g.dir.x || d.y != -g.dir.y) {
+			g.queueDir(d.x, d.y)
+			return
+		}
+	}
+}
+
+func sign(v int) int {
+	switch {
+	case v < 0:
+		return -1
+	case v > 0:
+		return 1
+	}
+	return 0
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
 func (g *snakeGame) queueDir(dx, dy int) {
 	if -dx == g.dir.x && -dy == g.dir.y {
 		return // nie zawracamy w miejscu
 	}
 	g.nextDir = snakePoint{dx, dy}
+	g.inputs = append(g.inputs, snakeInput{tick: g.ticks, dir: g.nextDir})
 }
 
+// nextHead: w trybie wrap wyjście za krawędź wraca z drugiej strony
 func (g *snakeGame) nextHead() snakePoint {
 	head := g.snake[0]
-	return snakePoint{x: head.x + g.dir.x, y: head.y + g.dir.y}
+	next := snakePoint{x: head.x + g.dir.x, y: head.y + g.dir.y}
+	if g.mode.wrap {
+		next.x = (next.x + g.width) % g.width
+		next.y = (next.y + g.height) % g.height
+	}
+	return next
 }
 
 func (g *snakeGame) hitWall(p snakePoint) bool {
@@ -421,39 +1076,55 @@ func (g *snakeGame) hitSelf(p snakePoint) bool {
 	return false
 }
 
-func (g *snakeGame) spawnApple() {
-	for {
-		p := snakePoint{
-			x: g.random.Intn(g.width),
-			y: g.random.Intn(g.height),
-		}
-		if !g.hitSelf(p) && p != g.snake[0] {
-			g.apple = p
-			return
+// free to pole, na którym nic nie ma: ani snake'a, ani przeszkody, ani jabłek
+func (g *snakeGame) free(p snakePoint) bool {
+	return !g.hitSelf(p) && !g.obstacles[p] && p != g.apple && (g.bonusLeft == 0 || p != g.bonus)
+}
+
+// randomFree losuje wolne pole. Na zapchanej planszy może nie być żadnego,
+// wtedy ok=false zamiast kręcenia się w kółko
+func (g *snakeGame) randomFree() (snakePoint, bool) {
+	for range 1000 {
+		p := snakePoint{x: g.random.Intn(g.width), y: g.random.Intn(g.height)}
+		if g.free(p) {
+			return p, true
 		}
 	}
+	return snakePoint{}, false
+}
+
+func (g *snakeGame) spawnApple() {
+	g.apple = snakePoint{x: -1, y: -1}
+	if p, ok := g.randomFree(); ok {
+		g.apple = p
+	}
 }
 
-// drawScreensaver generuje random ascii tv
-func drawScreensaver(now time.Time) string {
-	width := 30
-	height := 10
-	seed := now.UnixNano() / int64(1*time.Millisecond)
+// maybeSpawnBonus: co któreś jabłko wyskakuje bonus na chwilę
+func (g *snakeGame) maybeSpawnBonus() {
+	if g.bonusLeft > 0 || g.random.Intn(snakeBonusChance) != 0 {
+		return
+	}
+	if p, ok := g.randomFree(); ok {
+		g.bonus = p
+		g.bonusLeft = snakeBonusTicks
+	}
+}
+
+// screensaverFrame to jak długo wisi jedna klatka szumu
+const screensaverFrame = 80 * time.Millisecond
+
+// drawScreensaver generuje random ascii tv, jedna klatka na seed
+func drawScreensaver(glyphs Glyphs, seed int64, width, height int) string {
 	r := rand.New(rand.NewSource(seed))
 
 	var b strings.Builder
 	for y := 0; y < height; y++ {
 		for x := 0; x < width; x++ {
-			switch r.Intn(6) {
-			case 0:
-				b.WriteString("▒")
-			case 1:
-				b.WriteString("▓")
-			case 2:
-				b.WriteString("░")
-			case 3:
-				b.WriteString("┼")
-			default:
+			// 4 z 6 to szum, reszta pusta
+			if n := r.Intn(6); n < len(glyphs.Static) {
+				b.WriteString(glyphs.Static[n])
+			} else {
 				b.WriteString(" ")
 			}
 		}
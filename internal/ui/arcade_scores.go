@@ -0,0 +1,271 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pcstyle/ssh-server/internal/store"
+)
+
+// boardSize to ile miejsc ma każda tabelka, top 10 jak w salonie
+const boardSize = 10
+
+// ArcadeRules to granice dla wyników z arcade, serwer daje je leaderboardowi.
+// Każdy tryb snake'a to osobna gra: max bonus na tick, nie szybciej niż
+// minSpeed jego difficulty, i replay, który po symulacji daje ten sam wynik
+func ArcadeRules() map[string]store.Rules {
+	rules := make(map[string]store.Rules)
+	for _, mode := range snakeModes() {
+		rules[mode.id()] = store.Rules{
+			MinTick:    mode.difficulty().minSpeed,
+			MaxPerTick: max(snakeApplePoints, snakeBonusPoints),
+			Step:       snakeApplePoints,
+			Replay: func(code string) (int, int, error) {
+				return verifySnakeReplay(code, mode)
+			},
+		}
+	}
+	return rules
+}
+
+// scorePeriod to jedna tabelka: all-time, tydzień albo dzień
+type scorePeriod struct {
+	title string
+	since func(now time.Time) time.Time
+}
+
+// scorePeriods liczą się w UTC, żeby każdy widział tę samą tabelkę
+var scorePeriods = []scorePeriod{
+	{title: "scores.all_time", since: func(time.Time) time.Time { return time.Time{} }},
+	{title: "scores.week", since: startOfWeek},
+	{title: "scores.today", since: startOfDay},
+}
+
+func startOfDay(now time.Time) time.Time {
+	y, m, d := now.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// startOfWeek to poniedziałek, bo tydzień nie zaczyna się w niedzielę
+func startOfWeek(now time.Time) time.Time {
+	day := startOfDay(now)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// scoreBoard to stan ekranu z wynikami: tryb (indeks w snakeModes),
+// okres, i mine to świeżo wpisany wynik, podświetlony w tabelce
+type scoreBoard struct {
+	mode   int
+	period int
+	mine   *store.Score
+}
+
+// initialsPrompt to ekran "NEW HIGH SCORE" z trzema literkami do wpisania
+type initialsPrompt struct {
+	letters [3]byte
+	cursor  int
+	mode    snakeMode
+	pending store.Score
+}
+
+// initialsAlphabet to po czym kręcą strzałki góra/dół
+const initialsAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// spin przesuwa literkę pod kursorem o dir po alfabecie, w kółko
+func (p *initialsPrompt) spin(dir int) {
+	i := strings.IndexByte(initialsAlphabet, p.letters[p.cursor])
+	n := len(initialsAlphabet)
+	p.letters[p.cursor] = initialsAlphabet[((i+dir)%n+n)%n]
+}
+
+// typed wpisuje literkę z klawiatury i jedzie dalej
+func (p *initialsPrompt) typed(r rune) {
+	c := strings.ToUpper(string(r))
+	if len(c) != 1 || !strings.Contains(initialsAlphabet, c) {
+		return
+	}
+	p.letters[p.cursor] = c[0]
+	p.cursor = min(p.cursor+1, len(p.letters)-1)
+}
+
+// typing: w inicjałach i w kodzie replayu q, ? i : to zwykłe literki
+func (m ArcadeModel) typing() bool {
+	return m.state == arcadeStateInitials || m.state == arcadeStateReplayLoad
+}
+
+// gameOver: jak wynik łapie się do dzisiejszego top 10 (a więc do
+// każdego), to lecą inicjały, inaczej zwykłe rip
+func (m *ArcadeModel) gameOver() {
+	now := m.clock.Now()
+	mode := m.snake.mode
+	m.best[mode.id()] = max(m.best[mode.id()], m.snake.score)
+	if !m.scores.Qualifies(mode.id(), m.snake.score, startOfDay(now), boardSize) {
+		m.statusLine = "arcade.status.dead"
+		return
+	}
+
+	m.initials.cursor = 0
+	if m.initials.letters[0] == 0 {
+		m.initials.letters = [3]byte{'A', 'A', 'A'} // potem zostają ostatnie
+	}
+	m.initials.mode = mode
+	m.initials.pending = store.Score{
+		Game:        mode.id(),
+		Fingerprint: m.fingerprint,
+		Points:      m.snake.score,
+		At:          now,
+		Duration:    now.Sub(m.snakeStarted),
+		Ticks:       m.snake.ticks,
+		Replay:      m.snake.replay().encode(),
+		Settings: map[string]string{
+			"board": fmt.Sprintf("%dx%d", m.snake.width, m.snake.height),
+			"level": fmt.Sprint(m.snake.level),
+		},
+	}
+	m.state = arcadeStateInitials
+	m.statusLine = "arcade.status.high_score"
+}
+
+func (m ArcadeModel) handleInitialsKey(msg tea.KeyMsg) (ArcadeModel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, initialsKeys.Up):
+		m.initials.spin(-1)
+	case key.Matches(msg, initialsKeys.Down):
+		m.initials.spin(1)
+	case key.Matches(msg, initialsKeys.Left):
+		m.initials.cursor = max(m.initials.cursor-1, 0)
+	case key.Matches(msg, initialsKeys.Right):
+		m.initials.cursor = min(m.initials.cursor+1, len(m.initials.letters)-1)
+	case key.Matches(msg, initialsKeys.Save):
+		m.saveScore()
+	case key.Matches(msg, initialsKeys.Skip):
+		m.state = arcadeStateSnake
+		m.statusLine = "arcade.status.dead"
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1:
+		m.initials.typed(msg.Runes[0])
+	}
+	return m, nil
+}
+
+// saveScore wysyła wynik na leaderboard i pokazuje tabelkę, w której
+// wylądował najwyżej. Odrzucony wynik wraca do martwego snake'a
+func (m *ArcadeModel) saveScore() {
+	score := m.initials.pending
+	score.Name = string(m.initials.letters[:])
+	if err := m.scores.Submit(score); err != nil {
+		m.state = arcadeStateSnake
+		m.statusLine = "arcade.status.score_failed"
+		if errors.Is(err, store.ErrImplausible) {
+			m.statusLine = "arcade.status.score_rejected"
+		}
+		return
+	}
+
+	m.state = arcadeStateScores
+	m.statusLine = "arcade.status.score_saved"
+	m.board = scoreBoard{mode: modeIndex(m.initials.mode), period: len(scorePeriods) - 1, mine: &score}
+	for i, period := range scorePeriods {
+		if m.onBoard(period, score) {
+			m.board.period = i
+			break
+		}
+	}
+}
+
+// onBoard sprawdza czy s jest w tabelce za period
+func (m ArcadeModel) onBoard(period scorePeriod, s store.Score) bool {
+	for _, top := range m.scores.Top(s.Game, period.since(m.clock.Now()), boardSize) {
+		if sameScore(top, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameScore(a, b store.Score) bool {
+	return a.Name == b.Name && a.Points == b.Points && a.At.Equal(b.At) && a.Fingerprint == b.Fingerprint
+}
+
+func (m ArcadeModel) handleScoresKey(msg tea.KeyMsg) ArcadeModel {
+	n, modes := len(scorePeriods), len(snakeModes())
+	switch {
+	case key.Matches(msg, scoresKeys.PrevMode):
+		m.board.mode = (m.board.mode + modes - 1) % modes
+	case key.Matches(msg, scoresKeys.NextMode):
+		m.board.mode = (m.board.mode + 1) % modes
+	case key.Matches(msg, scoresKeys.Prev):
+		m.board.period = (m.board.period + n - 1) % n
+	case key.Matches(msg, scoresKeys.Next):
+		m.board.period = (m.board.period + 1) % n
+	case key.Matches(msg, scoresKeys.Back):
+		m.state = arcadeStateMenu
+		m.statusLine = "arcade.status.scores_left"
+	}
+	return m
+}
+
+func (m ArcadeModel) renderInitials() string {
+	var letters []string
+	for i, c := range m.initials.letters {
+		style := m.theme.Input
+		if i == m.initials.cursor {
+			style = m.theme.InputFocused
+		}
+		letters = append(letters, style.Render(string(c)))
+	}
+
+	lines := []string{
+		m.theme.Title.Render(m.tr.T("scores.new_high")) + "  " + m.theme.Help.UnsetMarginTop().Render(m.initials.mode.title(m.tr)),
+		m.tr.T("snake.score", m.initials.pending.Points),
+		m.tr.T("scores.enter_initials"),
+		strings.Join(letters, " "),
+		keyHelp(m.theme, m.tr, m.width, m.KeyMap()),
+	}
+	if m.statusLine != "" {
+		lines = append(lines, m.theme.RenderHelp(m.width, m.tr.T(m.statusLine)))
+	}
+	return m.theme.BoxFor(m.width).Render(strings.Join(lines, "\n\n"))
+}
+
+func (m ArcadeModel) renderScores() string {
+	var tabs []string
+	for i, period := range scorePeriods {
+		style := m.theme.NavItem
+		if i == m.board.period {
+			style = m.theme.NavItemSelected
+		}
+		tabs = append(tabs, zone(fmt.Sprintf("scores.period.%d", i), style.Render(m.tr.T(period.title))))
+	}
+
+	mode := snakeModes()[m.board.mode]
+	period := scorePeriods[m.board.period]
+	top := m.scores.Top(mode.id(), period.since(m.clock.Now()), boardSize)
+
+	var rows []string
+	for i, s := range top {
+		row := fmt.Sprintf("%2d. %-3s %7d  %s", i+1, s.Name, s.Points, s.At.UTC().Format("2006-01-02"))
+		if m.board.mine != nil && sameScore(s, *m.board.mine) {
+			row = m.theme.NavItemSelected.Render(row + "  " + m.tr.T("scores.you"))
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		rows = append(rows, m.theme.Help.UnsetMarginTop().Render(m.tr.T("scores.empty")))
+	}
+
+	lines := []string{
+		m.theme.Title.Render(m.tr.T("scores.title")),
+		zone("scores.mode", m.theme.NavItem.Render(m.tr.T("scores.mode", mode.title(m.tr)))),
+		strings.Join(tabs, "  "),
+		strings.Join(rows, "\n"),
+		keyHelp(m.theme, m.tr, m.width, m.KeyMap()),
+	}
+	if m.statusLine != "" {
+		lines = append(lines, m.theme.RenderHelp(m.width, m.tr.T(m.statusLine)))
+	}
+	return m.theme.BoxFor(m.width).Render(strings.Join(lines, "\n\n"))
+}
@@ -0,0 +1,426 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
+	"github.com/pcstyle/ssh-server/internal/api"
+	"github.com/pcstyle/ssh-server/internal/store"
+)
+
+const (
+	// A cached feed younger than this is shown without asking the site again
+	feedFreshFor = 5 * time.Minute
+
+	// Posts per page of the list
+	blogPageSize = 10
+)
+
+// BlogModel lists the posts of the site's feed and shows one at a time
+type BlogModel struct {
+	apiClient *api.Client
+	feedURL   string
+	cache     *store.FeedCache
+	clock     Clock
+
+	loading   bool
+	feed      *api.Feed
+	fetchedAt time.Time
+	stale     bool
+	err       error
+
+	cursor   int
+	open     bool
+	body     string
+	viewport viewport.Model
+	width    int
+	height   int
+	tr       *Translator
+	theme    *Theme
+}
+
+// feedLoadedMsg carries the feed, either fresh or from the cache. err is
+// set whenever fetching failed, even if a stale copy could be served.
+type feedLoadedMsg struct {
+	feed      *api.Feed
+	fetchedAt time.Time
+	stale     bool
+	err       error
+}
+
+func init() {
+	// No feed configured, no blog
+	RegisterScreen("blog", 20, func(env ScreenEnv) []Screen {
+		if env.Session.FeedURL == "" {
+			return nil
+		}
+		return []Screen{NewBlogModel(env.API, env.Session, env.Tr, env.Theme)}
+	})
+}
+
+// NewBlogModel creates the blog view for the feed at feedURL
+func NewBlogModel(apiClient *api.Client, session Session, tr *Translator, theme *Theme) BlogModel {
+	vp := viewport.New(0, 0)
+	vp.KeyMap = blogPostKeys.Scroll
+	return BlogModel{
+		apiClient: apiClient,
+		feedURL:   session.FeedURL,
+		cache:     session.Feeds,
+		clock:     session.Clock,
+		viewport:  vp,
+		tr:        tr,
+		theme:     theme,
+	}
+}
+
+// ID identifies the blog
+func (m BlogModel) ID() View {
+	return ViewBlog
+}
+
+// Title is the menu entry
+func (m BlogModel) Title() string {
+	return m.tr.T("menu.blog.title")
+}
+
+// Description is shown next to the menu entry
+func (m BlogModel) Description() string {
+	return m.tr.T("menu.blog.desc")
+}
+
+// Hidden is false, the blog is only built when there's a feed
+func (m BlogModel) Hidden() bool {
+	return false
+}
+
+// Unlock is empty, the blog is never hidden
+func (m BlogModel) Unlock() []string {
+	return nil
+}
+
+// Init has nothing to do, the feed loads on Enter
+func (m BlogModel) Init() tea.Cmd {
+	return nil
+}
+
+// Enter loads the feed unless this session fetched it moments ago. Back
+// from history the post being read stays open and the feed isn't touched.
+func (m BlogModel) Enter(how Arrival) (Screen, tea.Cmd) {
+	if how == ArriveHistory {
+		return m, nil
+	}
+	m.open = false
+	if m.loading || (m.feed != nil && !m.stale && m.clock.Now().Sub(m.fetchedAt) < feedFreshFor) {
+		return m, nil
+	}
+	cmd := m.refresh(false)
+	return m, cmd
+}
+
+// Leave has nothing to do; a feed still loading is delivered anyway
+func (m BlogModel) Leave() (Screen, tea.Cmd) {
+	return m, nil
+}
+
+// crumb is the title of the open post
+func (m BlogModel) crumb() string {
+	if !m.open || m.cursor >= len(m.posts()) {
+		return ""
+	}
+	return m.posts()[m.cursor].Title
+}
+
+// refresh starts a fetch. Unless forced, a fresh enough cached copy is used
+// instead of the network.
+func (m *BlogModel) refresh(force bool) tea.Cmd {
+	m.loading = true
+	m.err = nil
+
+	client, url, cache, clock := m.apiClient, m.feedURL, m.cache, m.clock
+	return func() tea.Msg {
+		if !force {
+			if cached, ok := cache.Load(url); ok && clock.Now().Sub(cached.FetchedAt) < feedFreshFor {
+				return feedLoadedMsg{feed: &cached.Feed, fetchedAt: cached.FetchedAt}
+			}
+		}
+
+		feed, err := client.FetchFeed(url)
+		if err == nil {
+			if err := cache.Save(url, *feed); err != nil {
+				log.Warn("Failed to cache feed", "error", err)
+			}
+			return feedLoadedMsg{feed: feed, fetchedAt: clock.Now()}
+		}
+
+		log.Warn("Failed to fetch feed", "url", url, "error", err)
+		if cached, ok := cache.Load(url); ok {
+			return feedLoadedMsg{feed: &cached.Feed, fetchedAt: cached.FetchedAt, stale: true, err: err}
+		}
+		return feedLoadedMsg{err: err}
+	}
+}
+
+// Update handles the list, the open post and feed results
+func (m BlogModel) Update(msg tea.Msg) (Screen, tea.Cmd) {
+	switch typed := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = typed.Width
+		m.height = typed.Height
+		m.resize()
+		return m, nil
+
+	case feedLoadedMsg:
+		m.loading = false
+		m.err = typed.err
+		if typed.feed != nil {
+			m.feed = typed.feed
+			m.fetchedAt = typed.fetchedAt
+			m.stale = typed.stale
+			if m.cursor >= len(m.feed.Posts) {
+				m.cursor = 0
+			}
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.open {
+			return m.updatePost(typed)
+		}
+		return m.updateList(typed)
+	}
+
+	if m.open {
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m BlogModel) updateList(msg tea.KeyMsg) (BlogModel, tea.Cmd) {
+	posts := m.posts()
+
+	switch {
+	case key.Matches(msg, blogKeys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(msg, blogKeys.Down):
+		if m.cursor < len(posts)-1 {
+			m.cursor++
+		}
+	case key.Matches(msg, blogKeys.PrevPage):
+		m.cursor = max(m.cursor-blogPageSize, 0)
+	case key.Matches(msg, blogKeys.NextPage):
+		if len(posts) > 0 {
+			m.cursor = min(m.cursor+blogPageSize, len(posts)-1)
+		}
+	case key.Matches(msg, blogKeys.Refresh):
+		if !m.loading {
+			cmd := m.refresh(true)
+			return m, cmd
+		}
+	case key.Matches(msg, blogKeys.Open):
+		if len(posts) == 0 {
+			return m, nil
+		}
+		m.open = true
+		m.render()
+		m.viewport.GotoTop()
+	case key.Matches(msg, blogKeys.Back):
+		return m, func() tea.Msg { return BackMsg{} }
+	}
+	return m, nil
+}
+
+// updatePost closes the post or jumps to either end; scrolling and paging
+// are the viewport's, with blogPostKeys.Scroll as its keymap
+func (m BlogModel) updatePost(msg tea.KeyMsg) (BlogModel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, blogPostKeys.Close):
+		m.open = false
+		return m, nil
+	case key.Matches(msg, blogPostKeys.Top):
+		m.viewport.GotoTop()
+		return m, nil
+	case key.Matches(msg, blogPostKeys.Bottom):
+		m.viewport.GotoBottom()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// KeyMap is what works on the list or the open post right now
+func (m BlogModel) KeyMap() help.KeyMap {
+	if m.open {
+		return blogPostKeys
+	}
+
+	keys := blogKeys
+	posts := len(m.posts())
+	keys.Up.SetEnabled(posts > 1)
+	keys.Down.SetEnabled(posts > 1)
+	keys.Open.SetEnabled(posts > 0)
+	keys.PrevPage.SetEnabled(posts > blogPageSize)
+	keys.NextPage.SetEnabled(posts > blogPageSize)
+	keys.Refresh.SetEnabled(!m.loading)
+	return keys
+}
+
+func (m BlogModel) posts() []api.Post {
+	if m.feed == nil {
+		return nil
+	}
+	return m.feed.Posts
+}
+
+// resize gives the post whatever the box, title, meta line and help leave
+func (m *BlogModel) resize() {
+	m.viewport.Width = m.textWidth()
+	m.viewport.Height = max(m.height-12, 3)
+	if m.open {
+		m.render()
+	}
+}
+
+// textWidth is the room for post text inside the box
+func (m BlogModel) textWidth() int {
+	if m.width == 0 {
+		return 76
+	}
+	return clamp(m.width-8, 20, 100)
+}
+
+// render converts the open post to terminal text for the current width
+func (m *BlogModel) render() {
+	posts := m.posts()
+	if m.cursor >= len(posts) {
+		return
+	}
+	post := posts[m.cursor]
+	m.body = renderMarkdown(htmlToMarkdown(post.Content, post.Link), m.theme, m.textWidth())
+	m.viewport.SetContent(m.body)
+}
+
+// View renders the post list or the open post
+func (m BlogModel) View() string {
+	if m.open && m.cursor < len(m.posts()) {
+		return m.postView(m.posts()[m.cursor])
+	}
+
+	var b strings.Builder
+	title := m.tr.T("menu.blog.title")
+	if m.feed != nil && m.feed.Title != "" {
+		title = m.feed.Title
+	}
+	b.WriteString(m.theme.Title.Render(title))
+	b.WriteString("\n")
+
+	if m.stale {
+		b.WriteString(m.theme.Error.UnsetPadding().Render(m.tr.T("blog.stale", m.fetchedAt.Format("2006-01-02 15:04"))))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	posts := m.posts()
+	switch {
+	case m.loading && m.feed == nil:
+		b.WriteString(m.theme.Help.UnsetMarginTop().Render(m.tr.T("blog.loading")))
+		b.WriteString("\n")
+	case m.feed == nil && m.err != nil:
+		b.WriteString(m.theme.Error.UnsetPadding().Render(m.tr.T("blog.error", m.err.Error())))
+		b.WriteString("\n")
+		b.WriteString(keyHelp(m.theme, m.tr, m.width, m.KeyMap()))
+		return m.theme.BoxFor(m.width).Render(b.String())
+	case len(posts) == 0:
+		b.WriteString(m.theme.Help.UnsetMarginTop().Render(m.tr.T("blog.empty")))
+		b.WriteString("\n")
+	}
+
+	page := m.cursor / blogPageSize
+	start := page * blogPageSize
+	end := min(start+blogPageSize, len(posts))
+	for i := start; i < end; i++ {
+		post := posts[i]
+		cursor := "  "
+		itemStyle := m.theme.NavItem
+		if i == m.cursor {
+			cursor = m.theme.NavArrow.Render(m.theme.Glyphs.Arrow + " ")
+			itemStyle = m.theme.NavItemSelected
+		}
+
+		postTitle := post.Title
+		if compact(m.width) {
+			postTitle = truncate(postTitle, m.width-24)
+		}
+		b.WriteString(fmt.Sprintf("%s%s %s\n", cursor, m.theme.Help.UnsetMarginTop().Render(postDate(post)), itemStyle.Render(postTitle)))
+	}
+
+	if pages := (len(posts) + blogPageSize - 1) / blogPageSize; pages > 1 {
+		b.WriteString("\n")
+		b.WriteString(m.theme.Help.UnsetMarginTop().Render(m.tr.T("blog.page", page+1, pages)))
+		b.WriteString("\n")
+	}
+
+	var status []string
+	if m.loading && m.feed != nil {
+		status = append(status, m.tr.T("blog.loading"))
+	}
+	b.WriteString(keyHelp(m.theme, m.tr, m.width, m.KeyMap(), status...))
+
+	return m.theme.BoxFor(m.width).Render(b.String())
+}
+
+func (m BlogModel) postView(post api.Post) string {
+	var b strings.Builder
+	b.WriteString(m.theme.Title.Render(post.Title))
+	b.WriteString("\n")
+
+	meta := postDate(post)
+	if post.Link != "" {
+		meta += " " + m.theme.Glyphs.Bullet + " " + post.Link
+	}
+	b.WriteString(m.theme.Help.UnsetMarginTop().Width(m.textWidth()).Render(meta))
+	b.WriteString("\n\n")
+
+	vp := m.viewport
+	vp.SetContent(m.body)
+	b.WriteString(vp.View())
+	b.WriteString("\n")
+
+	pages := max((vp.TotalLineCount()+vp.Height-1)/max(vp.Height, 1), 1)
+	current := vp.YOffset/max(vp.Height, 1) + 1
+	if vp.AtBottom() {
+		current = pages
+	}
+	b.WriteString(keyHelp(m.theme, m.tr, m.width, m.KeyMap(), m.tr.T("blog.post.page", current, pages)))
+
+	return m.theme.BoxFor(m.width).Render(b.String())
+}
+
+// postDate is the post's publish date, blank when the feed didn't say
+func postDate(post api.Post) string {
+	if post.Published.IsZero() {
+		return strings.Repeat(" ", len("2006-01-02"))
+	}
+	return post.Published.Format("2006-01-02")
+}
+
+// truncate shortens s to width cells with an ellipsis
+func truncate(s string, width int) string {
+	width = max(width, 8)
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
@@ -0,0 +1,240 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// ColorTier is how many colors a visitor's terminal can show
+type ColorTier int
+
+const (
+	TierMono ColorTier = iota
+	Tier16
+	Tier256
+	TierTrueColor
+)
+
+// String returns the tier name used in logs
+func (t ColorTier) String() string {
+	switch t {
+	case TierTrueColor:
+		return "truecolor"
+	case Tier256:
+		return "256"
+	case Tier16:
+		return "16"
+	default:
+		return "mono"
+	}
+}
+
+// Capabilities describes what a session's terminal can render
+type Capabilities struct {
+	Color ColorTier
+
+	// ASCII is set when box drawing and block characters would show up
+	// as garbage, e.g. on serial consoles or non-UTF-8 locales
+	ASCII bool
+
+	// Hyperlinks means OSC 8 links are safe to send. Terminals that don't
+	// know them mostly ignore them, these are the ones that print junk.
+	Hyperlinks bool
+
+	// Clipboard means OSC 52 copy is worth trying. Whether the terminal
+	// actually allows it can't be asked over SSH.
+	Clipboard bool
+
+	// Screen is set inside GNU screen, which needs OSC 52 wrapped to pass
+	// it on to the real terminal
+	Screen bool
+}
+
+// asciiTerms are TERM values known to lack (or mangle) Unicode glyphs
+var asciiTerms = map[string]bool{
+	"":        true,
+	"dumb":    true,
+	"unknown": true,
+	"ansi":    true,
+	"cygwin":  true,
+	"vt52":    true,
+	"vt100":   true,
+	"vt102":   true,
+	"vt220":   true,
+	"vt320":   true,
+	"cons25":  true,
+	"sun":     true,
+}
+
+// DetectCapabilities picks a rendering tier from the PTY's TERM, the
+// client's environment and the color profile the renderer settled on
+func DetectCapabilities(term string, env []string, profile termenv.Profile) Capabilities {
+	term = strings.ToLower(term)
+	vars := envMap(env)
+
+	caps := Capabilities{Color: tierFromProfile(profile)}
+	if vars["NO_COLOR"] != "" || strings.HasSuffix(term, "-mono") || strings.HasSuffix(term, "-m") || term == "dumb" {
+		caps.Color = TierMono
+	}
+
+	caps.ASCII = asciiTerms[term] || !utf8Locale(vars)
+
+	// The Linux console and old screen/tmux (TERM=screen*) print OSC 8
+	// instead of hiding it; OSC 52 fails quietly, so only hopeless
+	// terminals lose it
+	caps.Screen = strings.HasPrefix(term, "screen") && vars["TMUX"] == ""
+	caps.Hyperlinks = !caps.ASCII && term != "linux" && !strings.HasPrefix(term, "screen")
+	caps.Clipboard = !caps.ASCII && term != "linux"
+	return caps
+}
+
+func tierFromProfile(profile termenv.Profile) ColorTier {
+	switch profile {
+	case termenv.TrueColor:
+		return TierTrueColor
+	case termenv.ANSI256:
+		return Tier256
+	case termenv.ANSI:
+		return Tier16
+	default:
+		return TierMono
+	}
+}
+
+// utf8Locale reports whether the client's locale can show Unicode. Most
+// clients don't forward LANG at all, so no locale means "assume UTF-8".
+func utf8Locale(vars map[string]string) bool {
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		value := strings.ToLower(vars[name])
+		if value == "" {
+			continue
+		}
+		if value == "c" || value == "posix" {
+			return false
+		}
+		_, charset, ok := strings.Cut(value, ".")
+		if !ok {
+			return true
+		}
+		return strings.HasPrefix(charset, "utf-8") || strings.HasPrefix(charset, "utf8")
+	}
+	return true
+}
+
+func envMap(env []string) map[string]string {
+	vars := make(map[string]string, len(env))
+	for _, kv := range env {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			vars[k] = v
+		}
+	}
+	return vars
+}
+
+// Glyphs are the characters views draw frames, the snake and the
+// screensaver with, swapped wholesale for ASCII-only terminals
+type Glyphs struct {
+	TopLeft     string
+	TopRight    string
+	BottomLeft  string
+	BottomRight string
+	Horizontal  string
+	Vertical    string
+	Rule        string
+
+	SnakeHead string
+	SnakeBody string
+	Apple     string
+	Bonus     string
+	Obstacle  string
+	Crumb     string
+
+	// Static is the screensaver noise, picked at random per cell
+	Static []string
+
+	Arrow   string
+	Chevron string
+	Dot     string
+	Bullet  string
+	Quote   string
+}
+
+var unicodeGlyphs = Glyphs{
+	TopLeft:     "╔",
+	TopRight:    "╗",
+	BottomLeft:  "╚",
+	BottomRight: "╝",
+	Horizontal:  "═",
+	Vertical:    "║",
+	Rule:        "━",
+	SnakeHead:   "■",
+	SnakeBody:   "░",
+	Apple:       "●",
+	Bonus:       "◆",
+	Obstacle:    "▓",
+	Crumb:       "·",
+	Static:      []string{"▒", "▓", "░", "┼"},
+	Arrow:       "→",
+	Chevron:     "›",
+	Dot:         "●",
+	Bullet:      "•",
+	Quote:       "│",
+}
+
+var asciiGlyphs = Glyphs{
+	TopLeft:     "+",
+	TopRight:    "+",
+	BottomLeft:  "+",
+	BottomRight: "+",
+	Horizontal:  "-",
+	Vertical:    "|",
+	Rule:        "-",
+	SnakeHead:   "@",
+	SnakeBody:   "o",
+	Apple:       "*",
+	Bonus:       "$",
+	Obstacle:    "#",
+	Crumb:       ".",
+	Static:      []string{"%", "#", ":", "+"},
+	Arrow:       ">",
+	Chevron:     ">",
+	Dot:         "*",
+	Bullet:      "*",
+	Quote:       "|",
+}
+
+// asciiFold catches the symbols that live in catalog strings (help lines,
+// status marks). Every replacement is one column wide so borders that
+// lipgloss already measured stay aligned.
+var asciiFold = strings.NewReplacer(
+	"→", ">",
+	"←", "<",
+	"↑", "^",
+	"↓", "v",
+	"•", "*",
+	"●", "*",
+	"━", "-",
+	"·", ".",
+	"✓", "+",
+	"✗", "x",
+	"–", "-",
+	"—", "-",
+	"…", ".",
+)
+
+// frame draws a box around lines, padding each one to width
+func (g Glyphs) frame(lines []string, width int) string {
+	var b strings.Builder
+	b.WriteString(g.TopLeft + strings.Repeat(g.Horizontal, width) + g.TopRight + "\n")
+	for _, line := range lines {
+		pad := width - lipgloss.Width(line)
+		if pad < 0 {
+			pad = 0
+		}
+		b.WriteString(g.Vertical + line + strings.Repeat(" ", pad) + g.Vertical + "\n")
+	}
+	b.WriteString(g.BottomLeft + strings.Repeat(g.Horizontal, width) + g.BottomRight)
+	return b.String()
+}
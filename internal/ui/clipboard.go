@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+)
+
+// How long a toast stays on screen
+const toastDuration = 2500 * time.Millisecond
+
+// CopyMsg asks the app to copy Text to the visitor's local clipboard
+type CopyMsg struct {
+	Text string
+}
+
+// toastExpiredMsg hides the toast with the same id
+type toastExpiredMsg struct{ id int }
+
+// copyTarget is what copying a link puts on the clipboard: the address for
+// mailto links, the URL for the rest
+func copyTarget(target string) string {
+	return strings.TrimPrefix(target, "mailto:")
+}
+
+// copyCmd copies text with OSC 52 and shows a toast saying so. Terminals
+// without OSC 52 get the text in the toast instead, to select by hand.
+func (m *Model) copyCmd(text string) tea.Cmd {
+	if !m.session.Caps.Clipboard || m.session.Terminal == nil {
+		return m.showToast(m.tr.T("toast.copy.unsupported", text))
+	}
+
+	seq := osc52.New(text)
+	if m.session.Caps.Screen {
+		seq = seq.Screen()
+	}
+	out := m.session.Terminal
+	write := func() tea.Msg {
+		if _, err := seq.WriteTo(out); err != nil {
+			log.Warn("Failed to write clipboard sequence", "error", err)
+		}
+		return nil
+	}
+	return tea.Batch(write, m.showToast(m.tr.T("toast.copied", text)))
+}
+
+// showToast shows text under the current view for a moment
+func (m *Model) showToast(text string) tea.Cmd {
+	m.toastID++
+	m.toast = text
+	id := m.toastID
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return toastExpiredMsg{id: id}
+	})
+}
+
+// withToast puts the toast, if any, under content
+func (m Model) withToast(content string) string {
+	if m.toast == "" || m.quitting {
+		return content
+	}
+	style := m.theme.Success.UnsetPadding()
+	if m.width > 0 && lipgloss.Width(m.toast) > m.width-4 {
+		style = style.Width(m.width - 4)
+	}
+	return lipgloss.JoinVertical(lipgloss.Center, content, style.Render(m.toast))
+}
@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Clock tells views the time. Sessions run on the wall clock; tests and
+// replays pass one they control through Session.Clock.
+type Clock interface {
+	Now() time.Time
+}
+
+// Seeds starts random sources. Every game, shuffle and bit of screen noise
+// asks for a seed of its own and draws from it alone, so the seed is all
+// it takes to play the same thing again.
+type Seeds interface {
+	Seed() int64
+}
+
+// SystemClock is the wall clock
+type SystemClock struct{}
+
+// Now returns time.Now
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// SystemSeeds are unpredictable, a different game every time
+type SystemSeeds struct{}
+
+// Seed returns a random seed
+func (SystemSeeds) Seed() int64 {
+	return rand.Int63()
+}
+
+// ManualClock only moves when told to. It's safe to read from commands
+// running on other goroutines.
+type ManualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewManualClock returns a clock stopped at now
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+// Now returns the time the clock is stopped at
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// seededSeeds hands out the same sequence of seeds for the same start
+type seededSeeds struct {
+	mu     sync.Mutex
+	random *rand.Rand
+}
+
+// NewSeeds returns seeds that follow from seed, for tests and for
+// challenges everyone plays the same way (seed it with the date for a
+// daily one)
+func NewSeeds(seed int64) Seeds {
+	return &seededSeeds{random: rand.New(rand.NewSource(seed))}
+}
+
+// Seed returns the next seed in the sequence
+func (s *seededSeeds) Seed() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.random.Int63()
+}
+
+// newRand starts a random source from the next seed
+func newRand(seeds Seeds) *rand.Rand {
+	return rand.New(rand.NewSource(seeds.Seed()))
+}
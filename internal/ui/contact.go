@@ -1,12 +1,20 @@
 package ui
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
 	"github.com/pcstyle/ssh-server/internal/api"
+	"github.com/pcstyle/ssh-server/internal/store"
 )
 
 // Field indices
@@ -16,14 +24,40 @@ const (
 	fieldEmail
 	fieldDiscord
 	fieldPhone
+	fieldFacebook
 	fieldSubmit
 	fieldBack
 	fieldCount
 )
 
+// Dense form layout kicks in below this terminal height (or when compact)
+const (
+	denseFormHeight = 34
+	denseLabelWidth = 13
+)
+
 // BackMsg is sent when user wants to go back
 type BackMsg struct{}
 
+// contactStage tracks where the visitor is in the send flow
+type contactStage int
+
+const (
+	contactStageForm contactStage = iota
+	contactStageConfirm
+	contactStageReceipt
+	contactStageRestore
+	contactStageResume
+)
+
+// Confirm screen actions
+const (
+	confirmEdit = iota
+	confirmSend
+	confirmCancel
+	confirmCount
+)
+
 // ContactModel represents the contact form
 type ContactModel struct {
 	inputs        []textinput.Model
@@ -35,57 +69,141 @@ type ContactModel struct {
 	submitted     bool
 	submitSuccess bool
 	submitMessage string
+	fieldErrors   map[int]error
+	stage         contactStage
+	confirmFocus  int
+	reference     string
+	showQR        bool   // receipt shows the contact card as a QR code
+	hover         string // zone under the mouse pointer
+	session       Session
+	resumeCode    string
+	resumeFails   int // wrong codes typed this session, see maxResumeTries
+	draftKey      string
+	draftGen      int
+	pendingDraft  store.Draft
+	codeInput     textinput.Model
+	codeError     string
+	tr            *Translator
+	theme         *Theme
+}
+
+// contactLabels and contactPlaceholders are catalog keys, indexed by field
+var (
+	contactLabels = []string{
+		fieldMessage:  "contact.label.message",
+		fieldName:     "contact.label.name",
+		fieldEmail:    "contact.label.email",
+		fieldDiscord:  "contact.label.discord",
+		fieldPhone:    "contact.label.phone",
+		fieldFacebook: "contact.label.facebook",
+	}
+	contactPlaceholders = []string{
+		fieldMessage:  "contact.placeholder.message",
+		fieldName:     "contact.placeholder.name",
+		fieldEmail:    "contact.placeholder.email",
+		fieldDiscord:  "contact.placeholder.discord",
+		fieldPhone:    "contact.placeholder.phone",
+		fieldFacebook: "contact.placeholder.facebook",
+	}
+)
+
+func init() {
+	RegisterScreen("contact", 10, func(env ScreenEnv) []Screen {
+		return []Screen{NewContactModel(env.API, env.Session, env.Tr, env.Theme)}
+	})
 }
 
 // NewContactModel creates a new contact form model
-func NewContactModel(apiClient *api.Client) ContactModel {
+func NewContactModel(apiClient *api.Client, session Session, tr *Translator, theme *Theme) ContactModel {
 	m := ContactModel{
-		inputs:    make([]textinput.Model, fieldCount-2), // Exclude submit and back buttons
-		apiClient: apiClient,
+		inputs:      make([]textinput.Model, fieldCount-2), // Exclude submit and back buttons
+		apiClient:   apiClient,
+		fieldErrors: make(map[int]error),
+		session:     session,
+		tr:          tr,
+		theme:       theme,
 	}
 
+	// Visitors without a public key get a resume code for their draft
+	if session.Fingerprint == "" {
+		m.resumeCode = store.NewResumeCode()
+	}
+	m.draftKey = session.draftKey(m.resumeCode)
+
+	// Resume code prompt
+	m.codeInput = textinput.New()
+	m.codeInput.Placeholder = "ABCDE23456"
+	m.codeInput.CharLimit = store.ResumeCodeLength + 4
+	m.codeInput.Width = store.ResumeCodeLength + 4
+
 	// Message field (required)
 	m.inputs[fieldMessage] = textinput.New()
-	m.inputs[fieldMessage].Placeholder = "Enter your message here..."
-	m.inputs[fieldMessage].CharLimit = 2000
+	m.inputs[fieldMessage].CharLimit = maxMessageLen - utf8.RuneCountInString(contactTag)
 	m.inputs[fieldMessage].Width = 60
 	m.inputs[fieldMessage].Focus()
 
 	// Name field
 	m.inputs[fieldName] = textinput.New()
-	m.inputs[fieldName].Placeholder = "Your name (optional)"
 	m.inputs[fieldName].CharLimit = 100
 	m.inputs[fieldName].Width = 60
 
 	// Email field
 	m.inputs[fieldEmail] = textinput.New()
-	m.inputs[fieldEmail].Placeholder = "your.email@example.com (optional)"
 	m.inputs[fieldEmail].CharLimit = 100
 	m.inputs[fieldEmail].Width = 60
 
 	// Discord field
 	m.inputs[fieldDiscord] = textinput.New()
-	m.inputs[fieldDiscord].Placeholder = "@yourusername (optional)"
 	m.inputs[fieldDiscord].CharLimit = 100
 	m.inputs[fieldDiscord].Width = 60
 
 	// Phone field
 	m.inputs[fieldPhone] = textinput.New()
-	m.inputs[fieldPhone].Placeholder = "+1234567890 (optional)"
 	m.inputs[fieldPhone].CharLimit = 50
 	m.inputs[fieldPhone].Width = 60
 
+	// Facebook field
+	m.inputs[fieldFacebook] = textinput.New()
+	m.inputs[fieldFacebook].CharLimit = 200
+	m.inputs[fieldFacebook].Width = 60
+
 	return m
 }
 
+// ID identifies the contact form
+func (m ContactModel) ID() View {
+	return ViewContact
+}
+
+// Title is the menu entry
+func (m ContactModel) Title() string {
+	return m.tr.T("menu.contact.title")
+}
+
+// Description is shown next to the menu entry
+func (m ContactModel) Description() string {
+	return m.tr.T("menu.contact.desc")
+}
+
+// Hidden is false, anyone can write
+func (m ContactModel) Hidden() bool {
+	return false
+}
+
+// Unlock is empty, the form is never hidden
+func (m ContactModel) Unlock() []string {
+	return nil
+}
+
 // SubmitMsg is sent when the form is being submitted
 type SubmitMsg struct{}
 
 // SubmitResultMsg is sent when submission completes
 type SubmitResultMsg struct {
-	Success bool
-	Message string
-	Error   error
+	Success   bool
+	Message   string
+	Reference string
+	Error     error
 }
 
 // Init initializes the contact model
@@ -94,11 +212,22 @@ func (m ContactModel) Init() tea.Cmd {
 }
 
 // Update handles messages for the contact model
-func (m ContactModel) Update(msg tea.Msg) (ContactModel, tea.Cmd) {
+func (m ContactModel) Update(msg tea.Msg) (Screen, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "esc":
+		switch m.stage {
+		case contactStageConfirm:
+			return m.updateConfirm(msg)
+		case contactStageReceipt:
+			return m.updateReceipt(msg)
+		case contactStageRestore:
+			return m.updateRestore(msg)
+		case contactStageResume:
+			return m.updateResume(msg)
+		}
+
+		switch {
+		case key.Matches(msg, contactKeys.Back):
 			if !m.submitting {
 				return m, func() tea.Msg {
 					return BackMsg{}
@@ -106,63 +235,40 @@ func (m ContactModel) Update(msg tea.Msg) (ContactModel, tea.Cmd) {
 			}
 			return m, nil
 
-		case "tab", "shift+tab", "up", "down":
+		case key.Matches(msg, contactKeys.Resume):
+			if m.session.Fingerprint == "" {
+				return m.openResumePrompt()
+			}
+			return m, nil
+
+		case key.Matches(msg, contactKeys.Next, contactKeys.Prev):
 			if m.submitting {
 				return m, nil
 			}
 
 			// Navigate between fields
-			if msg.String() == "up" || msg.String() == "shift+tab" {
-				m.focusIndex--
-			} else {
-				m.focusIndex++
-			}
-
-			if m.focusIndex > fieldBack {
-				m.focusIndex = 0
-			} else if m.focusIndex < 0 {
-				m.focusIndex = fieldBack
+			next := m.focusIndex + 1
+			if key.Matches(msg, contactKeys.Prev) {
+				next = m.focusIndex - 1
 			}
-
-			// Update focus
-			cmds := make([]tea.Cmd, len(m.inputs))
-			for i := 0; i < len(m.inputs); i++ {
-				if i == m.focusIndex {
-					cmds[i] = m.inputs[i].Focus()
-				} else {
-					m.inputs[i].Blur()
-				}
+			if next > fieldBack {
+				next = 0
+			} else if next < 0 {
+				next = fieldBack
 			}
-			return m, tea.Batch(cmds...)
+			cmd := m.focus(next)
+			return m, cmd
 
-		case "enter":
+		case key.Matches(msg, contactKeys.Press):
 			if m.submitting {
 				return m, nil
 			}
-
-			// Handle submit button
-			if m.focusIndex == fieldSubmit {
-				// Validate message field
-				if strings.TrimSpace(m.inputs[fieldMessage].Value()) == "" {
-					m.submitSuccess = false
-					m.submitMessage = "Message is required!"
-					m.submitted = true
-					return m, nil
-				}
-
-				m.submitting = true
-				m.submitted = false
-				return m, m.submitForm()
-			}
-
-			// Handle back button
-			if m.focusIndex == fieldBack {
-				return m, func() tea.Msg {
-					return BackMsg{}
-				}
-			}
+			return m.press()
 		}
 
+	case zoneMsg:
+		return m.updateMouse(msg)
+
 	case SubmitResultMsg:
 		m.submitting = false
 		m.submitted = true
@@ -172,34 +278,304 @@ func (m ContactModel) Update(msg tea.Msg) (ContactModel, tea.Cmd) {
 		} else {
 			m.submitMessage = msg.Message
 		}
+
+		// Failed sends stay on the confirm screen so Send can be retried
+		if msg.Success && msg.Error == nil {
+			m.stage = contactStageReceipt
+			m.reference = msg.Reference
+			cmd := m.deleteDraft()
+			return m, cmd
+		}
+		return m, nil
+
+	case draftSaveMsg:
+		// Only the latest scheduled save actually writes
+		if msg.gen == m.draftGen {
+			return m, m.saveDraft()
+		}
 		return m, nil
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.resize()
 	}
 
 	// Update the focused input
-	if m.focusIndex < len(m.inputs) {
+	if m.stage == contactStageForm && m.focusIndex < len(m.inputs) {
+		before := m.inputs[m.focusIndex].Value()
+
 		var cmd tea.Cmd
 		m.inputs[m.focusIndex], cmd = m.inputs[m.focusIndex].Update(msg)
+
+		// Once a field has an error, re-check it as the user types
+		if _, ok := m.fieldErrors[m.focusIndex]; ok {
+			m.validateField(m.focusIndex)
+		}
+
+		if m.inputs[m.focusIndex].Value() != before {
+			saveCmd := m.scheduleDraftSave()
+			return m, tea.Batch(cmd, saveCmd)
+		}
 		return m, cmd
 	}
 
 	return m, nil
 }
 
+// focus moves the focus to field, checking the one it leaves so errors
+// show up right away
+func (m *ContactModel) focus(field int) tea.Cmd {
+	if field != m.focusIndex {
+		m.validateField(m.focusIndex)
+	}
+	m.focusIndex = field
+
+	cmds := make([]tea.Cmd, len(m.inputs))
+	for i := 0; i < len(m.inputs); i++ {
+		if i == m.focusIndex {
+			cmds[i] = m.inputs[i].Focus()
+		} else {
+			m.inputs[i].Blur()
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// press activates the focused button; on a field it does nothing
+func (m ContactModel) press() (ContactModel, tea.Cmd) {
+	switch m.focusIndex {
+	case fieldSubmit:
+		// Validate every field, errors are shown inline
+		if !m.validateAll() {
+			m.submitSuccess = false
+			m.submitMessage = m.tr.T("contact.fix_fields")
+			m.submitted = true
+			return m, nil
+		}
+
+		// Review before anything leaves the server. The reference is
+		// picked now so the review shows the message exactly as sent, and
+		// never one an inbox thread already has.
+		for m.reference == "" || m.session.Inbox.Taken(m.reference) {
+			m.reference = api.ReferenceID(uint32(m.session.Seeds.Seed()))
+		}
+		m.stage = contactStageConfirm
+		m.confirmFocus = confirmSend
+		m.submitted = false
+		return m, nil
+
+	case fieldBack:
+		return m, func() tea.Msg {
+			return BackMsg{}
+		}
+	}
+	return m, nil
+}
+
+// updateMouse focuses the field or button clicked, and presses buttons.
+// Pointer moves only change which button is highlighted.
+func (m ContactModel) updateMouse(msg zoneMsg) (ContactModel, tea.Cmd) {
+	m.hover = msg.zone
+	if !msg.clicked() || m.submitting {
+		return m, nil
+	}
+
+	switch m.stage {
+	case contactStageForm:
+		field, ok := zoneIndex(msg.zone, "contact.field.")
+		if !ok || field > fieldBack {
+			return m, nil
+		}
+		cmd := m.focus(field)
+		m, pressCmd := m.press()
+		return m, tea.Batch(cmd, pressCmd)
+
+	case contactStageConfirm:
+		if action, ok := zoneIndex(msg.zone, "contact.confirm."); ok && action < confirmCount {
+			m.confirmFocus = action
+			return m.activateConfirm()
+		}
+	}
+	return m, nil
+}
+
+// button renders a button in zone name: active when focused, highlighted
+// under the pointer
+func (m ContactModel) button(name, label string, focused bool) string {
+	style := m.theme.Button
+	switch {
+	case focused:
+		style = m.theme.ButtonActive
+	case m.hover == name:
+		style = m.theme.ButtonHover
+	}
+	return zone(name, style.Render(label))
+}
+
+// updateConfirm handles keys on the review screen
+func (m ContactModel) updateConfirm(msg tea.KeyMsg) (ContactModel, tea.Cmd) {
+	if m.submitting {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, confirmKeys.Prev):
+		m.confirmFocus = (m.confirmFocus + confirmCount - 1) % confirmCount
+	case key.Matches(msg, confirmKeys.Next):
+		m.confirmFocus = (m.confirmFocus + 1) % confirmCount
+	case key.Matches(msg, confirmKeys.Edit):
+		m.confirmFocus = confirmEdit
+		return m.activateConfirm()
+	case key.Matches(msg, confirmKeys.Confirm):
+		return m.activateConfirm()
+	}
+
+	return m, nil
+}
+
+// activateConfirm runs the focused confirm action
+func (m ContactModel) activateConfirm() (ContactModel, tea.Cmd) {
+	switch m.confirmFocus {
+	case confirmEdit:
+		m.stage = contactStageForm
+		m.submitted = false
+		m.focusIndex = fieldMessage
+		return m, m.inputs[fieldMessage].Focus()
+	case confirmSend:
+		m.submitting = true
+		m.submitted = false
+		return m, m.submitForm()
+	case confirmCancel:
+		// Cancelling discards the message, draft included
+		cmd := m.deleteDraft()
+		m.reset()
+		return m, tea.Batch(cmd, func() tea.Msg {
+			return BackMsg{}
+		})
+	}
+	return m, nil
+}
+
+// updateReceipt waits for the visitor to leave the receipt screen
+func (m ContactModel) updateReceipt(msg tea.KeyMsg) (ContactModel, tea.Cmd) {
+	if m.showQR {
+		m.showQR = false
+		return m, nil
+	}
+	switch {
+	case key.Matches(msg, receiptKeys.QR):
+		m.showQR = true
+	case key.Matches(msg, receiptKeys.Back):
+		m.reset()
+		return m, func() tea.Msg {
+			return BackMsg{}
+		}
+	}
+	return m, nil
+}
+
+// KeyMap is what works in the current stage of the form
+func (m ContactModel) KeyMap() help.KeyMap {
+	switch m.stage {
+	case contactStageConfirm:
+		return confirmKeys
+	case contactStageReceipt:
+		return receiptKeys
+	case contactStageRestore:
+		return restoreKeys
+	case contactStageResume:
+		return resumeKeys
+	}
+	keys := contactKeys
+	keys.Resume.SetEnabled(m.session.Fingerprint == "")
+	return keys
+}
+
+// typing reports whether keys go into a text field, so ? is just a
+// question mark there
+func (m ContactModel) typing() bool {
+	return m.stage == contactStageResume || (m.stage == contactStageForm && m.focusIndex < len(m.inputs))
+}
+
+// reset clears the form so the next visit starts fresh
+func (m *ContactModel) reset() {
+	fresh := NewContactModel(m.apiClient, m.session, m.tr, m.theme)
+	fresh.width = m.width
+	fresh.height = m.height
+	fresh.resize()
+	fresh.resumeCode = m.resumeCode
+	fresh.resumeFails = m.resumeFails
+	fresh.draftKey = m.draftKey
+	*m = fresh
+}
+
+// dense reports whether the form should put labels next to inputs and drop
+// the spacing, so all fields fit on narrow or short terminals
+func (m ContactModel) dense() bool {
+	return compact(m.width) || (m.height > 0 && m.height < denseFormHeight)
+}
+
+// resize fits the inputs to the window
+func (m *ContactModel) resize() {
+	if m.width == 0 {
+		return
+	}
+	width := clamp(m.width-16, 20, 60)
+	if m.dense() {
+		width = clamp(m.width-denseLabelWidth-18, 12, 60)
+	}
+	for i := range m.inputs {
+		m.inputs[i].Width = width
+	}
+}
+
+// validateField updates the inline error for a single input
+func (m *ContactModel) validateField(field int) bool {
+	if field >= len(m.inputs) {
+		return true
+	}
+
+	if err := validateContactField(field, m.inputs[field].Value()); err != nil {
+		m.fieldErrors[field] = err
+		return false
+	}
+
+	delete(m.fieldErrors, field)
+	return true
+}
+
+// validateAll checks every input and reports whether the form can be sent
+func (m *ContactModel) validateAll() bool {
+	valid := true
+	for i := range m.inputs {
+		if !m.validateField(i) {
+			valid = false
+		}
+	}
+	return valid
+}
+
+// buildRequest collects the form values into an API request, the message
+// tagged with its reference
+func (m ContactModel) buildRequest() api.ContactRequest {
+	return api.ContactRequest{
+		Message:  api.TagMessage(m.reference, strings.TrimSpace(m.inputs[fieldMessage].Value())),
+		Name:     strings.TrimSpace(m.inputs[fieldName].Value()),
+		Email:    strings.TrimSpace(m.inputs[fieldEmail].Value()),
+		Discord:  strings.TrimSpace(m.inputs[fieldDiscord].Value()),
+		Phone:    strings.TrimSpace(m.inputs[fieldPhone].Value()),
+		Facebook: normalizeFacebook(m.inputs[fieldFacebook].Value()),
+	}
+}
+
 // submitForm submits the contact form
 func (m ContactModel) submitForm() tea.Cmd {
+	req := m.buildRequest()
+	message := strings.TrimSpace(m.inputs[fieldMessage].Value())
+	reference := m.reference
+	session := m.session
 	return func() tea.Msg {
-		req := api.ContactRequest{
-			Message:  strings.TrimSpace(m.inputs[fieldMessage].Value()),
-			Name:     strings.TrimSpace(m.inputs[fieldName].Value()),
-			Email:    strings.TrimSpace(m.inputs[fieldEmail].Value()),
-			Discord:  strings.TrimSpace(m.inputs[fieldDiscord].Value()),
-			Phone:    strings.TrimSpace(m.inputs[fieldPhone].Value()),
-		}
-
 		resp, err := m.apiClient.SubmitContact(req)
 		if err != nil {
 			return SubmitResultMsg{
@@ -208,80 +584,199 @@ func (m ContactModel) submitForm() tea.Cmd {
 			}
 		}
 
+		// Key-authenticated visitors get a thread the owner can reply to
+		if resp.Success && session.Fingerprint != "" {
+			if err := session.Inbox.Start(session.Fingerprint, reference, threadSubject(message), message); err != nil {
+				log.Warn("Failed to start inbox thread", "error", err)
+			}
+		}
+
 		return SubmitResultMsg{
-			Success: resp.Success,
-			Message: resp.Message,
+			Success:   resp.Success,
+			Message:   resp.Message,
+			Reference: reference,
 		}
 	}
 }
 
 // View renders the contact form
 func (m ContactModel) View() string {
+	switch m.stage {
+	case contactStageConfirm:
+		return m.confirmView()
+	case contactStageReceipt:
+		return m.receiptView()
+	case contactStageRestore:
+		return m.restoreView()
+	case contactStageResume:
+		return m.resumeView()
+	}
+
 	var b strings.Builder
 
 	// Title
-	title := "Contact Form"
-	b.WriteString(TitleStyle.Render(title))
+	title := m.tr.T("contact.title")
+	b.WriteString(m.theme.Title.Render(title))
 	b.WriteString("\n\n")
 
 	// Show submission status
 	if m.submitted {
 		if m.submitSuccess {
-			b.WriteString(SuccessStyle.Render("✓ " + m.submitMessage))
+			b.WriteString(m.theme.Success.Render("✓ " + m.submitMessage))
 		} else {
-			b.WriteString(ErrorStyle.Render("✗ " + m.submitMessage))
+			b.WriteString(m.theme.Error.Render("✗ " + m.submitMessage))
 		}
 		b.WriteString("\n\n")
 	}
 
 	// Show loading state
 	if m.submitting {
-		b.WriteString(HelpStyle.Render("Submitting..."))
+		b.WriteString(m.theme.Help.Render(m.tr.T("contact.submitting")))
 		b.WriteString("\n")
-		return BaseStyle.Render(b.String())
+		return m.theme.Base.Render(b.String())
 	}
 
 	// Form fields
-	fields := []string{
-		"Message *",
-		"Name",
-		"Email",
-		"Discord",
-		"Phone",
-	}
-
-	for i, label := range fields {
-		// Label
-		labelStr := LabelStyle.Render(label + ":")
-		b.WriteString(labelStr)
-		b.WriteString("\n")
-
-		// Input
-		inputStyle := InputStyle
+	dense := m.dense()
+	for i, labelKey := range contactLabels {
+		inputStyle := m.theme.Input
 		if i == m.focusIndex {
-			inputStyle = InputFocusedStyle
+			inputStyle = m.theme.InputFocused
+		}
+		input := m.inputs[i]
+		input.Placeholder = m.tr.T(contactPlaceholders[i])
+
+		// Clicking the label or the input focuses the field
+		if dense {
+			// Label and input on one line
+			label := m.theme.Label.Width(denseLabelWidth).Render(m.tr.T(labelKey) + ":")
+			b.WriteString(zone(fieldZone(i), lipgloss.JoinHorizontal(lipgloss.Center, label, inputStyle.Render(input.View()))))
+			b.WriteString("\n")
+		} else {
+			b.WriteString(zone(fieldZone(i), m.theme.Label.Render(m.tr.T(labelKey)+":")+"\n"+inputStyle.Render(input.View())))
+			b.WriteString("\n")
+		}
+
+		// Inline validation error
+		if err, ok := m.fieldErrors[i]; ok {
+			b.WriteString(m.theme.FieldError.Render("✗ " + localizeError(m.tr, err)))
+			b.WriteString("\n")
+		}
+		if !dense {
+			b.WriteString("\n")
 		}
-		b.WriteString(inputStyle.Render(m.inputs[i].View()))
-		b.WriteString("\n\n")
 	}
 
 	// Buttons
-	submitButton := ButtonStyle.Render("Submit")
-	if m.focusIndex == fieldSubmit {
-		submitButton = ButtonActiveStyle.Render("Submit")
+	submitButton := m.button(fieldZone(fieldSubmit), m.tr.T("contact.submit"), m.focusIndex == fieldSubmit)
+	backButton := m.button(fieldZone(fieldBack), m.tr.T("contact.back"), m.focusIndex == fieldBack)
+	b.WriteString(fmt.Sprintf("%s  %s\n", submitButton, backButton))
+
+	// Help text
+	b.WriteString("\n")
+	b.WriteString(keyHelp(m.theme, m.tr, m.width, m.KeyMap()))
+	b.WriteString("\n")
+	b.WriteString(m.theme.RenderHelp(m.width, m.draftHint()))
+
+	return m.theme.Base.Render(b.String())
+}
+
+// confirmView shows the payload exactly as it will be posted
+func (m ContactModel) confirmView() string {
+	var b strings.Builder
+
+	b.WriteString(m.theme.Title.Render(m.tr.T("contact.review.title")))
+	b.WriteString("\n\n")
+
+	if m.submitted && !m.submitSuccess {
+		b.WriteString(m.theme.Error.Render("✗ " + m.submitMessage))
+		b.WriteString("\n\n")
 	}
 
-	backButton := ButtonStyle.Render("Back")
-	if m.focusIndex == fieldBack {
-		backButton = ButtonActiveStyle.Render("Back")
+	if m.submitting {
+		b.WriteString(m.theme.Help.Render(m.tr.T("contact.sending")))
+		b.WriteString("\n")
+		return m.theme.Base.Render(b.String())
 	}
 
-	b.WriteString(fmt.Sprintf("%s  %s\n", submitButton, backButton))
+	b.WriteString(m.theme.Label.Render("POST " + m.apiClient.ContactEndpoint()))
+	b.WriteString("\n")
+	b.WriteString(m.theme.Payload.Render(formatPayload(m.buildRequest())))
+	b.WriteString("\n\n")
 
-	// Help text
+	// Be upfront about where the data goes
+	b.WriteString(m.theme.Help.Render(m.tr.T("contact.review.where")))
+	b.WriteString("\n")
+	b.WriteString(m.theme.Help.Render(m.tr.T("contact.review.what")))
+	b.WriteString("\n\n")
+
+	labels := []string{m.tr.T("contact.review.edit"), m.tr.T("contact.review.send"), m.tr.T("contact.review.cancel")}
+	buttons := make([]string, len(labels))
+	for i, label := range labels {
+		buttons[i] = m.button(fmt.Sprintf("contact.confirm.%d", i), label, i == m.confirmFocus)
+	}
+	b.WriteString(strings.Join(buttons, "  "))
+	b.WriteString("\n\n")
+
+	b.WriteString(keyHelp(m.theme, m.tr, m.width, m.KeyMap()))
+
+	return m.theme.Base.Render(b.String())
+}
+
+// receiptView confirms delivery and shows the reference to quote later
+func (m ContactModel) receiptView() string {
+	if m.showQR {
+		card := vCard(ownerName, ownerEmail, ownerURL, m.tr.T("qr.card.note", m.reference))
+		return qrView(m.theme, m.tr, m.width, m.height, m.tr.T("contact.receipt.title"), card, ownerEmail)
+	}
+
+	var b strings.Builder
+
+	b.WriteString(m.theme.Title.Render(m.tr.T("contact.receipt.title")))
+	b.WriteString("\n\n")
+	b.WriteString(m.theme.Success.Render("✓ " + m.submitMessage))
+	b.WriteString("\n\n")
+
+	b.WriteString(m.theme.Label.Render(m.tr.T("contact.receipt.reference")))
+	b.WriteString(m.theme.NavItemSelected.Render(m.reference))
+	b.WriteString("\n\n")
+	b.WriteString(m.theme.Help.Render(m.tr.T("contact.receipt.quote")))
 	b.WriteString("\n")
-	helpText := "Use Tab/↑/↓ to navigate • Enter to submit/go back • Esc to cancel"
-	b.WriteString(HelpStyle.Render(helpText))
+	if m.session.Fingerprint != "" && m.session.Inbox != nil {
+		b.WriteString(m.theme.Help.Render(m.tr.T("contact.receipt.inbox")))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	b.WriteString(keyHelp(m.theme, m.tr, m.width, m.KeyMap()))
+
+	return m.theme.Base.Render(b.String())
+}
+
+// fieldZone names the mouse zone of a field or button
+func fieldZone(field int) string {
+	return fmt.Sprintf("contact.field.%d", field)
+}
 
-	return BaseStyle.Render(b.String())
+// formatPayload pretty-prints the request body for the confirm screen
+func formatPayload(req api.ContactRequest) string {
+	body, err := api.EncodeContact(req)
+	if err != nil {
+		return err.Error()
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, body, "", "  "); err != nil {
+		return string(body)
+	}
+	return out.String()
+}
+
+// threadSubject shortens a message into an inbox thread title
+func threadSubject(message string) string {
+	subject := strings.Join(strings.Fields(message), " ")
+	if runes := []rune(subject); len(runes) > 48 {
+		subject = string(runes[:48]) + "…"
+	}
+	return subject
 }
@@ -0,0 +1,260 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
+	"github.com/pcstyle/ssh-server/internal/api"
+	"github.com/pcstyle/ssh-server/internal/store"
+)
+
+// draftSaveDelay debounces autosave so we don't hit the disk on every key
+const draftSaveDelay = time.Second
+
+// draftSaveMsg fires after typing stops; gen filters out stale saves
+type draftSaveMsg struct {
+	gen int
+}
+
+// Enter checks for an unsent draft when the visitor opens the form. Coming
+// back through history finds the form as it was, so the check is a no-op.
+func (m ContactModel) Enter(how Arrival) (Screen, tea.Cmd) {
+	if m.stage == contactStageForm && m.isEmpty() {
+		if draft, ok := m.session.Drafts.Load(m.draftKey); ok {
+			m.pendingDraft = draft
+			m.stage = contactStageRestore
+		}
+	}
+	return m, textinput.Blink
+}
+
+// Leave saves the draft right away; a pending autosave would fire while
+// another view is showing and never reach the form
+func (m ContactModel) Leave() (Screen, tea.Cmd) {
+	if m.stage != contactStageForm || m.session.Drafts == nil {
+		return m, nil
+	}
+	m.draftGen++
+	return m, m.saveDraft()
+}
+
+// scheduleDraftSave queues an autosave for the current form contents
+func (m *ContactModel) scheduleDraftSave() tea.Cmd {
+	if m.session.Drafts == nil {
+		return nil
+	}
+
+	m.draftGen++
+	gen := m.draftGen
+	return tea.Tick(draftSaveDelay, func(time.Time) tea.Msg {
+		return draftSaveMsg{gen: gen}
+	})
+}
+
+// saveDraft writes the form to the draft store, or wipes it once emptied
+func (m ContactModel) saveDraft() tea.Cmd {
+	if m.isEmpty() {
+		return m.deleteDraft()
+	}
+
+	drafts, key := m.session.Drafts, m.draftKey
+	draft := store.Draft{Request: m.rawRequest(), SavedAt: m.session.Clock.Now()}
+	return func() tea.Msg {
+		if err := drafts.Save(key, draft); err != nil {
+			log.Warn("Failed to save draft", "error", err)
+		}
+		return nil
+	}
+}
+
+// deleteDraft wipes the stored draft, used after sending or cancelling
+func (m *ContactModel) deleteDraft() tea.Cmd {
+	drafts, key := m.session.Drafts, m.draftKey
+	if drafts == nil {
+		return nil
+	}
+
+	// Bump the generation so a pending autosave can't bring it back
+	m.draftGen++
+	return func() tea.Msg {
+		if err := drafts.Delete(key); err != nil {
+			log.Warn("Failed to delete draft", "error", err)
+		}
+		return nil
+	}
+}
+
+// rawRequest captures the inputs as typed, without trimming or normalizing
+func (m ContactModel) rawRequest() api.ContactRequest {
+	return api.ContactRequest{
+		Message:  m.inputs[fieldMessage].Value(),
+		Name:     m.inputs[fieldName].Value(),
+		Email:    m.inputs[fieldEmail].Value(),
+		Discord:  m.inputs[fieldDiscord].Value(),
+		Phone:    m.inputs[fieldPhone].Value(),
+		Facebook: m.inputs[fieldFacebook].Value(),
+	}
+}
+
+// applyDraft fills the inputs from a restored draft
+func (m *ContactModel) applyDraft(draft store.Draft) {
+	req := draft.Request
+	values := map[int]string{
+		fieldMessage:  req.Message,
+		fieldName:     req.Name,
+		fieldEmail:    req.Email,
+		fieldDiscord:  req.Discord,
+		fieldPhone:    req.Phone,
+		fieldFacebook: req.Facebook,
+	}
+	for field, value := range values {
+		m.inputs[field].SetValue(value)
+	}
+}
+
+// isEmpty reports whether nothing has been typed yet
+func (m ContactModel) isEmpty() bool {
+	for _, input := range m.inputs {
+		if strings.TrimSpace(input.Value()) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// updateRestore handles the "restore your unsent draft?" prompt
+func (m ContactModel) updateRestore(msg tea.KeyMsg) (ContactModel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, restoreKeys.Restore):
+		m.applyDraft(m.pendingDraft)
+		m.pendingDraft = store.Draft{}
+		m.stage = contactStageForm
+		m.focusIndex = fieldMessage
+		return m, m.inputs[fieldMessage].Focus()
+	case key.Matches(msg, restoreKeys.Discard):
+		m.pendingDraft = store.Draft{}
+		m.stage = contactStageForm
+		cmd := m.deleteDraft()
+		return m, cmd
+	case key.Matches(msg, restoreKeys.Back):
+		// Leave the draft alone, maybe next time
+		m.stage = contactStageForm
+		return m, func() tea.Msg {
+			return BackMsg{}
+		}
+	}
+	return m, nil
+}
+
+// maxResumeTries is how many wrong resume codes a session gets before the
+// prompt stops looking them up
+const maxResumeTries = 5
+
+// openResumePrompt switches to the resume code input
+func (m ContactModel) openResumePrompt() (ContactModel, tea.Cmd) {
+	m.stage = contactStageResume
+	m.codeError = ""
+	m.codeInput.SetValue("")
+	return m, m.codeInput.Focus()
+}
+
+// updateResume looks up a draft by the code the visitor typed
+func (m ContactModel) updateResume(msg tea.KeyMsg) (ContactModel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, resumeKeys.Cancel):
+		m.stage = contactStageForm
+		m.codeInput.Blur()
+		return m, nil
+	case key.Matches(msg, resumeKeys.Lookup):
+		if m.resumeFails >= maxResumeTries {
+			m.codeError = m.tr.T("draft.locked")
+			return m, nil
+		}
+		code := store.NormalizeResumeCode(m.codeInput.Value())
+		key := m.session.draftKey(code)
+		draft, ok := m.session.Drafts.Load(key)
+		if code == "" || !ok {
+			// Only typos get a few more goes, not guessing other
+			// visitors' codes
+			m.resumeFails++
+			m.codeError = m.tr.T("draft.not_found")
+			if m.resumeFails >= maxResumeTries {
+				m.codeError = m.tr.T("draft.locked")
+			}
+			return m, nil
+		}
+
+		// Keep saving under the recovered code from now on
+		m.resumeCode = code
+		m.draftKey = key
+		m.pendingDraft = draft
+		m.stage = contactStageRestore
+		m.codeInput.Blur()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.codeInput, cmd = m.codeInput.Update(msg)
+	return m, cmd
+}
+
+// draftHint explains how the draft can be recovered after a disconnect
+func (m ContactModel) draftHint() string {
+	if m.session.Drafts == nil {
+		return ""
+	}
+	if m.session.Fingerprint != "" {
+		return m.tr.T("draft.hint.key")
+	}
+	return m.tr.T("draft.hint.code", m.resumeCode)
+}
+
+// restoreView offers to bring back an unsent draft
+func (m ContactModel) restoreView() string {
+	var b strings.Builder
+
+	b.WriteString(m.theme.Title.Render(m.tr.T("draft.restore.title")))
+	b.WriteString("\n\n")
+
+	saved := m.pendingDraft.SavedAt.Format("2006-01-02 15:04")
+	b.WriteString(m.theme.Help.Render(m.tr.T("draft.restore.saved", saved)))
+	b.WriteString("\n\n")
+
+	preview := m.pendingDraft.Request.Message
+	if len([]rune(preview)) > 120 {
+		preview = string([]rune(preview)[:120]) + "…"
+	}
+	b.WriteString(m.theme.Payload.Render(preview))
+	b.WriteString("\n\n")
+
+	b.WriteString(keyHelp(m.theme, m.tr, m.width, m.KeyMap()))
+
+	return m.theme.Base.Render(b.String())
+}
+
+// resumeView asks for a resume code shown during an earlier session
+func (m ContactModel) resumeView() string {
+	var b strings.Builder
+
+	b.WriteString(m.theme.Title.Render(m.tr.T("draft.resume.title")))
+	b.WriteString("\n\n")
+
+	b.WriteString(m.theme.Label.Render(m.tr.T("draft.resume.label")))
+	b.WriteString("\n")
+	b.WriteString(m.theme.InputFocused.Render(m.codeInput.View()))
+	b.WriteString("\n")
+
+	if m.codeError != "" {
+		b.WriteString(m.theme.FieldError.Render("✗ " + m.codeError))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(keyHelp(m.theme, m.tr, m.width, m.KeyMap()))
+
+	return m.theme.Base.Render(b.String())
+}
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pcstyle/ssh-server/internal/store"
+)
+
+func TestDraftsExpireOnSessionClock(t *testing.T) {
+	// Far from the wall clock, so a store on time.Now would see the draft as
+	// long expired (or not yet saved)
+	clock := NewManualClock(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+	drafts, err := store.NewDrafts(t.TempDir(), time.Hour, clock.Now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	session := Session{Fingerprint: "SHA256:test", Clock: clock, Drafts: drafts}
+	theme := NewTheme(lipgloss.NewRenderer(io.Discard), nil, Capabilities{})
+	open := func() ContactModel {
+		screen, _ := NewContactModel(nil, session, NewTranslator(LocaleEN), theme).Enter(ArriveNew)
+		return screen.(ContactModel)
+	}
+
+	m := open()
+	m.inputs[fieldMessage].SetValue("hello")
+	if _, cmd := m.Leave(); cmd != nil {
+		cmd()
+	}
+
+	clock.Advance(59 * time.Minute)
+	if m := open(); m.stage != contactStageRestore || m.pendingDraft.Request.Message != "hello" {
+		t.Fatalf("draft not offered before its TTL: stage %d", m.stage)
+	}
+
+	clock.Advance(2 * time.Minute)
+	if m := open(); m.stage != contactStageForm {
+		t.Errorf("draft offered past its TTL: stage %d", m.stage)
+	}
+}
@@ -0,0 +1,394 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/exp/teatest"
+	"github.com/muesli/termenv"
+	"github.com/pcstyle/ssh-server/internal/api"
+	"github.com/pcstyle/ssh-server/internal/arena"
+	"github.com/pcstyle/ssh-server/internal/content"
+	"github.com/pcstyle/ssh-server/internal/store"
+)
+
+// These tests run the whole app in a teatest program: a script of keys at
+// a fixed terminal size, then the last frame is compared with
+// testdata/<test name>.golden. After an intended UI change, regenerate the
+// files with
+//
+//	go test ./internal/ui -update
+//
+// and review the diff like any other change.
+
+// harness is one scripted session
+type harness struct {
+	t     *testing.T
+	tm    *teatest.TestModel
+	trace *trace
+}
+
+// trace records every message the app handled, so a script can wait for
+// timers (boot sequences, status flashes, delayed navigation) to land
+type trace struct {
+	mu   sync.Mutex
+	seen []tea.Msg
+}
+
+func (tr *trace) add(msg tea.Msg) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	tr.seen = append(tr.seen, msg)
+}
+
+func (tr *trace) find(match func(tea.Msg) bool) bool {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	for _, msg := range tr.seen {
+		if match(msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// traced is the app with its messages recorded
+type traced struct {
+	tea.Model
+	trace *trace
+}
+
+func (m traced) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.Model.Update(msg)
+	m.trace.add(msg)
+	return traced{Model: next, trace: m.trace}, cmd
+}
+
+// newHarness starts the app at width x height without colors, so goldens
+// are plain text. Unless the session says otherwise the clock is stopped
+// and seeds are fixed, so games and noise come out the same every run.
+func newHarness(t *testing.T, apiURL string, session Session, width, height int) *harness {
+	t.Helper()
+	if session.Clock == nil {
+		session.Clock = NewManualClock(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+	}
+	if session.Seeds == nil {
+		session.Seeds = NewSeeds(1)
+	}
+	renderer := lipgloss.NewRenderer(io.Discard)
+	renderer.SetColorProfile(termenv.Ascii)
+
+	tr := &trace{}
+	app := traced{Model: NewModel(apiURL, renderer, session), trace: tr}
+	tm := teatest.NewTestModel(t, app, teatest.WithInitialTermSize(width, height))
+	t.Cleanup(func() { _ = tm.Quit() })
+	return &harness{t: t, tm: tm, trace: tr}
+}
+
+// press sends named keys ("enter", "down", "esc", ...)
+func (h *harness) press(keys ...string) {
+	for _, k := range keys {
+		msg, ok := namedKeys[k]
+		if !ok {
+			h.t.Fatalf("unknown key %q", k)
+		}
+		h.tm.Send(msg)
+	}
+}
+
+var namedKeys = map[string]tea.KeyMsg{
+	"enter": {Type: tea.KeyEnter},
+	"tab":   {Type: tea.KeyTab},
+	"esc":   {Type: tea.KeyEsc},
+	"up":    {Type: tea.KeyUp},
+	"down":  {Type: tea.KeyDown},
+	"pgdn":  {Type: tea.KeyPgDown},
+}
+
+// typeText types s one rune at a time
+func (h *harness) typeText(s string) {
+	for _, r := range s {
+		h.tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+// waitFor waits until text shows up in the output
+func (h *harness) waitFor(text string) {
+	h.t.Helper()
+	teatest.WaitFor(h.t, h.tm.Output(), func(out []byte) bool {
+		return strings.Contains(string(out), text)
+	}, teatest.WithDuration(3*time.Second), teatest.WithCheckInterval(10*time.Millisecond))
+}
+
+// waitMsg waits until the app has handled a message matching match
+func (h *harness) waitMsg(what string, match func(tea.Msg) bool) {
+	h.t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for !h.trace.find(match) {
+		if time.Now().After(deadline) {
+			h.t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// golden stops the program and compares its last frame with the golden file
+func (h *harness) golden() {
+	h.t.Helper()
+	if err := h.tm.Quit(); err != nil {
+		h.t.Fatal(err)
+	}
+	final := h.tm.FinalModel(h.t, teatest.WithFinalTimeout(3*time.Second)).(traced)
+	teatest.RequireEqualOutput(h.t, []byte(final.Model.View()))
+}
+
+// library writes files (name -> contents) into a content library
+func library(t *testing.T, files map[string]string) *content.Library {
+	t.Helper()
+	dir := t.TempDir()
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lib, err := content.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lib
+}
+
+// navigatedTo matches the navigation to target, however it was started
+func navigatedTo(target View) func(tea.Msg) bool {
+	return func(msg tea.Msg) bool {
+		nav, ok := msg.(NavigateMsg)
+		return ok && nav.Target == target
+	}
+}
+
+func TestHomeNavigation(t *testing.T) {
+	session := Session{
+		Pages: library(t, map[string]string{
+			"about.md": "---\ntitle: About\norder: 1\n---\n# About\n\nHello from the **golden** tests.\n",
+		}),
+	}
+	h := newHarness(t, "http://127.0.0.1:0", session, 80, 24)
+
+	h.waitFor("About")
+	h.press("down", "enter")
+	h.waitFor("golden")
+	h.golden()
+}
+
+func TestScroll(t *testing.T) {
+	// The home menu doesn't fit 12 rows, so it scrolls
+	h := newHarness(t, "http://127.0.0.1:0", Session{}, 80, 12)
+
+	h.waitFor("0%")
+	h.press("pgdn")
+	h.waitFor("100%")
+	h.golden()
+}
+
+func TestSecretUnlock(t *testing.T) {
+	h := newHarness(t, "http://127.0.0.1:0", Session{}, 80, 30)
+
+	h.typeText("snake")
+	h.waitMsg("arcade to boot", func(msg tea.Msg) bool {
+		_, ok := msg.(arcadeBootMsg)
+		return ok
+	})
+	h.golden()
+}
+
+// contactReference is what the seeds in TestContactSubmit pick
+const contactReference = "SSH-07FCFD52"
+
+func TestContactSubmit(t *testing.T) {
+	var got api.ContactRequest
+	var mu sync.Mutex
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/contact" {
+			http.NotFound(w, r)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(api.ContactResponse{Success: true, Message: "Message received"})
+	}))
+	defer stub.Close()
+
+	h := newHarness(t, stub.URL, Session{Seeds: NewSeeds(1)}, 100, 40)
+
+	h.press("enter")
+	h.waitMsg("the contact form", navigatedTo(ViewContact))
+	h.typeText("Hello over SSH")
+	h.press("tab")
+	h.typeText("Ada")
+	h.press("tab")
+	h.typeText("ada@example.com")
+	h.press("tab", "tab", "tab", "tab", "enter")
+	h.waitFor("ada@example.com")
+	h.press("enter")
+	h.waitFor("Message received")
+	h.golden()
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := api.ContactRequest{Message: api.TagMessage(contactReference, "Hello over SSH"), Name: "Ada", Email: "ada@example.com", Source: "ssh"}
+	if got != want {
+		t.Errorf("API got %+v, want %+v", got, want)
+	}
+}
+
+func TestSnake(t *testing.T) {
+	h := newHarness(t, "http://127.0.0.1:0", Session{Seeds: NewSeeds(7)}, 100, 40)
+
+	h.typeText("snake")
+	h.waitMsg("arcade to boot", func(msg tea.Msg) bool {
+		_, ok := msg.(arcadeBootMsg)
+		return ok
+	})
+	// Normal mode from the picker, then straight into the ceiling: one
+	// tick up, the next one hits the wall
+	h.press("enter", "enter", "up")
+	h.waitFor("you died")
+	h.golden()
+}
+
+func TestArena(t *testing.T) {
+	// Rooms that won't tick before the golden is taken
+	hub := arena.NewHub(arena.Config{Tick: time.Minute, Countdown: 1, Seed: 1})
+	ctx, cancel := context.WithCancel(context.Background())
+	go hub.Run(ctx)
+	t.Cleanup(cancel)
+	if _, err := hub.QuickMatch("BOB", nil); err != nil {
+		t.Fatal(err)
+	}
+	h := newHarness(t, "http://127.0.0.1:0", Session{Arena: hub}, 100, 40)
+
+	h.typeText("snake")
+	h.waitMsg("arcade to boot", func(msg tea.Msg) bool {
+		_, ok := msg.(arcadeBootMsg)
+		return ok
+	})
+	// SNAKE VS, then quick match into Bob's room
+	h.press("down", "enter")
+	h.waitFor("room #1")
+	h.press("enter")
+	h.waitFor("round 1 starts in 60s")
+	h.golden()
+}
+
+func TestScreensaver(t *testing.T) {
+	h := newHarness(t, "http://127.0.0.1:0", Session{}, 80, 30)
+
+	h.typeText("snake")
+	h.waitMsg("arcade to boot", func(msg tea.Msg) bool {
+		_, ok := msg.(arcadeBootMsg)
+		return ok
+	})
+	h.press("down", "enter")
+	h.waitFor("completely useless")
+	h.golden()
+}
+
+func TestHighScores(t *testing.T) {
+	// Hand-made scores have no replays to check
+	rules := ArcadeRules()
+	for game, r := range rules {
+		r.Replay = nil
+		rules[game] = r
+	}
+	scores, err := store.NewLeaderboard(filepath.Join(t.TempDir(), "scores.json"), rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2025-06-01 is a Sunday, so the week started on May 26th
+	for _, s := range []store.Score{
+		{Name: "OLD", Points: 900, At: time.Date(2025, 1, 10, 20, 0, 0, 0, time.UTC)},
+		{Name: "WKD", Points: 400, At: time.Date(2025, 5, 28, 18, 0, 0, 0, time.UTC)},
+		{Name: "SUN", Points: 250, At: time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)},
+		{Name: "SAT", Points: 120, At: time.Date(2025, 5, 25, 23, 0, 0, 0, time.UTC)},
+	} {
+		s.Game, s.Ticks, s.Duration = "snake", 100, 100*defaultSnakeMode.difficulty().minSpeed
+		if err := scores.Submit(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := scores.Submit(store.Score{Game: "snake", Name: "BOT", Points: 5000, Ticks: 100, Duration: time.Second}); !errors.Is(err, store.ErrImplausible) {
+		t.Fatalf("5000 points in 100 ticks got %v, want it turned away", err)
+	}
+
+	h := newHarness(t, "http://127.0.0.1:0", Session{Scores: scores}, 80, 30)
+	h.typeText("snake")
+	h.waitMsg("arcade to boot", func(msg tea.Msg) bool {
+		_, ok := msg.(arcadeBootMsg)
+		return ok
+	})
+	// All-time, then over to this week
+	h.press("down", "down", "down", "enter")
+	h.waitFor("OLD")
+	h.press("tab")
+	h.waitFor("WKD")
+	h.golden()
+}
+
+func TestReplay(t *testing.T) {
+	h := newHarness(t, "http://127.0.0.1:0", Session{Seeds: NewSeeds(7)}, 100, 40)
+
+	h.typeText("snake")
+	h.waitMsg("arcade to boot", func(msg tea.Msg) bool {
+		_, ok := msg.(arcadeBootMsg)
+		return ok
+	})
+	// The game from TestSnake, then watched back at double speed
+	h.press("enter", "enter", "up")
+	h.waitFor("you died")
+	h.typeText("v+")
+	h.waitFor("THE END")
+	h.golden()
+}
+
+func TestSecretsLog(t *testing.T) {
+	session := Session{
+		Secrets: library(t, map[string]string{
+			"first.md":  "---\ntitle: first light\norder: 1\n---\nThe arcade was a **decoy**.\n",
+			"second.md": "---\ntitle: second wind\norder: 2\n---\nThe logbook picks where to open from the session's seeds.\n",
+			"third.md":  "---\ntitle: third rail\norder: 3\n---\nSame seeds, same entry.\n",
+		}),
+	}
+	h := newHarness(t, "http://127.0.0.1:0", session, 80, 30)
+
+	h.typeText("snake")
+	h.waitMsg("the arcade", navigatedTo(ViewArcade))
+	h.press("esc")
+	h.waitMsg("going back", func(msg tea.Msg) bool {
+		_, ok := msg.(BackMsg)
+		return ok
+	})
+	// Contact, Exit, Arcade *, ??? *
+	h.press("down", "down", "down", "enter")
+	h.waitMsg("the opening flash to clear", func(msg tea.Msg) bool {
+		_, ok := msg.(secretsBlinkMsg)
+		return ok
+	})
+	h.golden()
+}
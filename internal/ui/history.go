@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Lines above the views, taken by the breadcrumb bar
+const breadcrumbHeight = 1
+
+// Arrival says how a view came on screen, for its Enter hook
+type Arrival int
+
+const (
+	// ArriveNew is a visit from a NavigateMsg; views start fresh
+	ArriveNew Arrival = iota
+	// ArriveHistory is back or forward; views pick up where they were left
+	ArriveHistory
+)
+
+// history holds the screens behind and ahead of the current one, like a
+// browser. Navigating somewhere new drops the forward stack.
+type history struct {
+	back    []View
+	forward []View
+}
+
+// navigate leaves the current view for msg's target, remembering the way
+// back. Navigating to where the visitor already is only restarts the view.
+// Targets the session doesn't have, like a disabled screen, are ignored.
+func (m *Model) navigate(msg NavigateMsg) tea.Cmd {
+	if msg.Target == ViewExit {
+		m.quitting = true
+		return tea.Quit
+	}
+	to, ok := m.screens[msg.Target]
+	if !ok {
+		return nil
+	}
+
+	leave := m.leave()
+	if msg.Target != m.currentView {
+		m.history.back = append(m.history.back, m.currentView)
+		m.history.forward = nil
+	}
+	m.currentView = msg.Target
+	if p, ok := to.(player); ok && msg.Game != "" {
+		var cmd tea.Cmd
+		m.screens[msg.Target], cmd = p.Play(msg.Game)
+		return tea.Batch(leave, cmd)
+	}
+	return tea.Batch(leave, m.enter(ArriveNew))
+}
+
+// goBack returns to the previous view, keeping the one left for forward.
+// It reports false when there's nowhere to go back to.
+func (m *Model) goBack() (tea.Cmd, bool) {
+	if len(m.history.back) == 0 {
+		return nil, false
+	}
+	to := m.history.back[len(m.history.back)-1]
+	m.history.back = m.history.back[:len(m.history.back)-1]
+	m.history.forward = append(m.history.forward, m.currentView)
+	return m.revisit(to), true
+}
+
+// goForward undoes a goBack
+func (m *Model) goForward() (tea.Cmd, bool) {
+	if len(m.history.forward) == 0 {
+		return nil, false
+	}
+	to := m.history.forward[len(m.history.forward)-1]
+	m.history.forward = m.history.forward[:len(m.history.forward)-1]
+	m.history.back = append(m.history.back, m.currentView)
+	return m.revisit(to), true
+}
+
+func (m *Model) revisit(to View) tea.Cmd {
+	leave := m.leave()
+	m.currentView = to
+	return tea.Batch(leave, m.enter(ArriveHistory))
+}
+
+// enter runs the current screen's Enter hook
+func (m *Model) enter(how Arrival) tea.Cmd {
+	s, ok := m.screens[m.currentView]
+	if !ok {
+		return nil
+	}
+	var cmd tea.Cmd
+	m.screens[m.currentView], cmd = s.Enter(how)
+	return cmd
+}
+
+// leave runs the current screen's Leave hook
+func (m *Model) leave() tea.Cmd {
+	s, ok := m.screens[m.currentView]
+	if !ok {
+		return nil
+	}
+	var cmd tea.Cmd
+	m.screens[m.currentView], cmd = s.Leave()
+	return cmd
+}
+
+// breadcrumbs is the bar over the views: the way here from the home
+// screen, plus whatever the current view has open inside it. Crumbs that
+// don't fit are dropped from the left. Home has no bar.
+func (m Model) breadcrumbs() string {
+	if m.quitting || (m.currentView == ViewHome && len(m.history.back) == 0) {
+		return ""
+	}
+
+	var crumbs []string
+	for _, v := range slices.Concat(m.history.back, []View{m.currentView}) {
+		if s, ok := m.screens[v]; ok {
+			crumbs = append(crumbs, truncate(s.Title(), 24))
+		}
+	}
+	if inner := m.innerCrumb(); inner != "" {
+		crumbs = append(crumbs, truncate(inner, 24))
+	}
+
+	sep := m.theme.Help.UnsetMarginTop().Render(" " + m.theme.Glyphs.Chevron + " ")
+	last := len(crumbs) - 1
+	render := func(from int) string {
+		parts := make([]string, 0, len(crumbs)-from+1)
+		if from > 0 {
+			parts = append(parts, m.theme.Help.UnsetMarginTop().Render("…"))
+		}
+		for i := from; i <= last; i++ {
+			style := m.theme.Help.UnsetMarginTop()
+			if i == last {
+				style = m.theme.Label.UnsetMarginRight()
+			}
+			parts = append(parts, style.Render(crumbs[i]))
+		}
+		return strings.Join(parts, sep)
+	}
+
+	bar := render(0)
+	for from := 1; m.width > 0 && lipgloss.Width(bar) > m.width-2 && from < last; from++ {
+		bar = render(from)
+	}
+	return bar
+}
+
+// innerCrumb is what the current view has open: a game, a post, a project
+// or a conversation, "" at its top level
+func (m Model) innerCrumb() string {
+	if c, ok := m.current().(crumber); ok {
+		return c.crumb()
+	}
+	return ""
+}
@@ -6,26 +6,30 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
-// MenuItem opisuje menu entry, niby obvious ale trzeba
+// MenuItem opisuje menu entry, niby obvious ale trzeba.
+// Tytuł i opis bierzemy ze screena w Directory przy renderze, więc język się zgadza
 type MenuItem struct {
-	Title       string
-	Description string
-	Target      View
-	isSecret    bool
+	Target   View
+	isSecret bool
 }
 
-// NavigateMsg leci gdy wybierzesz coś z listy, simple
+// NavigateMsg leci gdy wybierzesz coś z listy, simple.
+// Game odpala grę od razu, tylko dla ekranów z Play (arcade)
 type NavigateMsg struct {
 	Target View
+	Game   string
 }
 
 // HomeModel pilnuje strony startowej, zero magii
 type HomeModel struct {
-	menuItems      []MenuItem
-	secretItems    []MenuItem
+	dir            *Directory
+	session        Session
 	cursor         int
 	width          int
 	height         int
@@ -33,48 +37,47 @@ type HomeModel struct {
 	secretBuffer   string
 	secretMessage  string
 	lastUnlockPing time.Time
+	unreadReplies  int
+	tr             *Translator
+	theme          *Theme
 }
 
-// NewHomeModel składa menu bazowe, plus secret stash
-func NewHomeModel() HomeModel {
-	base := []MenuItem{
-		{
-			Title:       "Contact",
-			Description: "Send me a message",
-			Target:      ViewContact,
-		},
-		{
-			Title:       "About",
-			Description: "Learn more about this project",
-			Target:      ViewAbout,
-		},
-		{
-			Title:       "Exit",
-			Description: "Disconnect from SSH",
-			Target:      ViewExit,
-		},
-	}
-
-	secrets := []MenuItem{
-		{
-			Title:       "Arcade",
-			Description: "play snake + dziwne rzeczy",
-			Target:      ViewArcade,
-			isSecret:    true,
-		},
-		{
-			Title:       "???",
-			Description: "weird logbook, nie oceniaj",
-			Target:      ViewSecrets,
-			isSecret:    true,
-		},
-	}
-
-	return HomeModel{
-		menuItems:   base,
-		secretItems: secrets,
-		cursor:      0,
+// NewHomeModel: menu składamy z Directory na bieżąco, bo sekrety
+// i Inbox pojawiają się w trakcie sesji
+func NewHomeModel(dir *Directory, session Session, tr *Translator, theme *Theme) HomeModel {
+	m := HomeModel{
+		dir:     dir,
+		session: session,
+		tr:      tr,
+		theme:   theme,
 	}
+	m.countReplies()
+	return m
+}
+
+// ID home to korzeń, zawsze jest
+func (m HomeModel) ID() View {
+	return ViewHome
+}
+
+// Title to "Home" w breadcrumbs
+func (m HomeModel) Title() string {
+	return m.tr.T("nav.home")
+}
+
+// Description home nie ma, nie jest w menu
+func (m HomeModel) Description() string {
+	return ""
+}
+
+// Hidden: home nie siedzi w menu samego siebie, ale i tak nie trzeba go odblokować
+func (m HomeModel) Hidden() bool {
+	return false
+}
+
+// Unlock nic, patrz wyżej
+func (m HomeModel) Unlock() []string {
+	return nil
 }
 
 // Init niby nic nie robi, ale bubbletea chce
@@ -82,28 +85,83 @@ func (m HomeModel) Init() tea.Cmd {
 	return nil
 }
 
+// Enter odświeża licznik odpowiedzi, mogły przyjść w międzyczasie
+func (m HomeModel) Enter(how Arrival) (Screen, tea.Cmd) {
+	m.countReplies()
+	m.cursor = min(m.cursor, len(m.menu())-1)
+	return m, nil
+}
+
+// Leave pusty
+func (m HomeModel) Leave() (Screen, tea.Cmd) {
+	return m, nil
+}
+
+// countReplies liczy nieprzeczytane odpowiedzi z inboxa
+func (m *HomeModel) countReplies() {
+	m.unreadReplies = 0
+	if m.session.Fingerprint == "" || m.session.Inbox == nil {
+		return
+	}
+	for _, t := range m.session.Inbox.Threads(m.session.Fingerprint) {
+		m.unreadReplies += t.Unread
+	}
+}
+
+// menu: najpierw zwykłe ekrany, potem Exit, sekrety na sam koniec
+func (m HomeModel) menu() []MenuItem {
+	var items, secrets []MenuItem
+	for _, s := range m.dir.Menu() {
+		if m.dir.Secret(s.ID()) {
+			secrets = append(secrets, MenuItem{Target: s.ID(), isSecret: true})
+		} else {
+			items = append(items, MenuItem{Target: s.ID()})
+		}
+	}
+	items = append(items, MenuItem{Target: ViewExit})
+	return append(items, secrets...)
+}
+
 // Update ogarnia klawisze, trochę też odpala sekrety
-func (m HomeModel) Update(msg tea.Msg) (HomeModel, tea.Cmd) {
+func (m HomeModel) Update(msg tea.Msg) (Screen, tea.Cmd) {
 	switch typed := msg.(type) {
 	case tea.KeyMsg:
 		if cmd := m.observeForSecrets(typed); cmd != nil {
 			return m, cmd
 		}
 
-		switch typed.String() {
-		case "up", "k":
+		switch {
+		case key.Matches(typed, homeKeys.Up):
 			if m.cursor > 0 {
 				m.cursor--
 			}
-		case "down", "j":
-			if m.cursor < len(m.menuItems)-1 {
+		case key.Matches(typed, homeKeys.Down):
+			if m.cursor < len(m.menu())-1 {
 				m.cursor++
 			}
-		case "enter", " ":
-			// send nav message, bo bubbletea tak lubi
-			item := m.menuItems[m.cursor]
-			return m, func() tea.Msg {
-				return NavigateMsg{Target: item.Target}
+		case key.Matches(typed, homeKeys.Language):
+			// przełącz język, reszta widoków czyta z tego samego translatora
+			m.tr.Toggle()
+		case key.Matches(typed, homeKeys.Theme):
+			// następna paleta, też globalnie dla sesji
+			m.theme.Cycle()
+		case key.Matches(typed, homeKeys.Quit):
+			// wyjście to też tylko nawigacja, app robi resztę
+			return m, func() tea.Msg { return NavigateMsg{Target: ViewExit} }
+		case key.Matches(typed, homeKeys.Select):
+			return m, m.selectItem()
+		}
+	case tea.MouseMsg:
+		// kółko myszy jak strzałki
+		if dir, ok := wheel(typed); ok {
+			m.cursor = clamp(m.cursor+dir, 0, len(m.menu())-1)
+		}
+	case zoneMsg:
+		// najechanie podświetla, klik od razu wybiera
+		if i, ok := zoneIndex(typed.zone, "home."); ok && i < len(m.menu()) {
+			m.cursor = i
+			if typed.clicked() {
+				return m, m.selectItem()
 			}
 		}
 	case tea.WindowSizeMsg:
@@ -114,6 +172,14 @@ func (m HomeModel) Update(msg tea.Msg) (HomeModel, tea.Cmd) {
 	return m, nil
 }
 
+// selectItem: send nav message, bo bubbletea tak lubi
+func (m HomeModel) selectItem() tea.Cmd {
+	item := m.menu()[m.cursor]
+	return func() tea.Msg {
+		return NavigateMsg{Target: item.Target}
+	}
+}
+
 func (m *HomeModel) observeForSecrets(key tea.KeyMsg) tea.Cmd {
 	str := strings.ToLower(key.String())
 
@@ -132,80 +198,146 @@ func (m *HomeModel) observeForSecrets(key tea.KeyMsg) tea.Cmd {
 		m.secretBuffer = m.secretBuffer[len(m.secretBuffer)-16:]
 	}
 
-	if strings.Contains(m.secretBuffer, "snake") || strings.Contains(m.secretBuffer, "games") {
-		if !m.secretUnlocked {
-			m.secretUnlocked = true
-			m.secretMessage = "ok... arcade booted, powodzenia"
-			m.lastUnlockPing = time.Now()
-			m.menuItems = append(m.menuItems, m.secretItems...)
-			return tea.Tick(420*time.Millisecond, func(time.Time) tea.Msg {
-				return NavigateMsg{Target: ViewArcade}
-			})
-		}
+	// każdy ekran ma swoje słowa (arcade: snake/games, strony: unlock z front matter),
+	// odblokowany leci na koniec menu i od razu tam skaczemy
+	unlocked := m.dir.Unlock(m.secretBuffer)
+	if len(unlocked) == 0 {
+		return nil
 	}
-
-	return nil
+	m.secretUnlocked = true
+	m.secretMessage = "home.unlocked"
+	m.lastUnlockPing = m.session.Clock.Now()
+	target := unlocked[0]
+	return tea.Tick(420*time.Millisecond, func(time.Time) tea.Msg {
+		return NavigateMsg{Target: target}
+	})
 }
 
 // View rysuje ekran główny
 func (m HomeModel) View() string {
 	var b strings.Builder
 
-	// ascii banner bo inaczej nudno
-	banner := `
-╔═══════════════════════════════════════╗
-║                                       ║
-║         P C S T Y L E . D E V         ║
-║                                       ║
-║         SSH Terminal Interface        ║
-║                                       ║
-╚═══════════════════════════════════════╝
-`
-
-	b.WriteString(TitleStyle.Render(banner))
+	// ascii banner bo inaczej nudno; na wąskim albo niskim terminalu bez ramki
+	banner := homeBanner(m.theme.Glyphs, m.tr.T("home.subtitle"))
+	if m.width > 0 && (m.width < lipgloss.Width(banner)+8 || m.height < 24) {
+		banner = "P C S T Y L E . D E V\n" + m.tr.T("home.subtitle") + "\n"
+	}
+	b.WriteString(m.theme.Title.Render(banner))
 	b.WriteString("\n")
 
-	// welcome, bo tak wypada
-	welcome := "Welcome to pcstyle.dev SSH interface"
-	b.WriteString(TitleStyle.Width(m.width).Render(welcome))
+	// welcome, bo tak wypada, wycentrowany pod bannerem
+	welcome := m.tr.T("home.welcome")
+	b.WriteString(m.theme.Title.Width(max(lipgloss.Width(banner), lipgloss.Width(welcome))).Render(welcome))
 	b.WriteString("\n\n")
 
+	// nowe odpowiedzi, niech widać od razu
+	if m.unreadReplies > 0 {
+		notice := m.tr.T("home.replies.one")
+		if m.unreadReplies > 1 {
+			notice = m.tr.T("home.replies.many", m.unreadReplies)
+		}
+		b.WriteString(m.theme.NavArrow.Render(m.theme.Glyphs.Dot + " " + notice))
+		b.WriteString("\n\n")
+	}
+
 	// navigation menu aka główne decyzje
-	for i, item := range m.menuItems {
+	descStyle := m.theme.Help.UnsetMarginTop()
+	for i, item := range m.menu() {
 		cursor := "  "
 		if i == m.cursor {
-			cursor = NavArrowStyle.Render("→ ")
+			cursor = m.theme.NavArrow.Render(m.theme.Glyphs.Arrow + " ")
 		}
 
 		// styling mix, nie pytaj
-		itemStyle := NavItemStyle
+		itemStyle := m.theme.NavItem
 		if i == m.cursor {
-			itemStyle = NavItemSelectedStyle
+			itemStyle = m.theme.NavItemSelected
 		}
 
-		title := itemStyle.Render(markSecretTitle(item))
-		desc := HelpStyle.Render(item.Description)
+		title := itemStyle.Render(m.markSecretTitle(item))
+		desc := descStyle.Render(m.itemDescription(item))
 
-		b.WriteString(fmt.Sprintf("%s%s - %s\n", cursor, title, desc))
+		// compact: opis tylko pod zaznaczonym, inaczej się nie mieści
+		var line string
+		switch {
+		case m.itemDescription(item) == "":
+			line = fmt.Sprintf("%s%s", cursor, title)
+		case !compact(m.width):
+			line = fmt.Sprintf("%s%s - %s", cursor, title, desc)
+		case i == m.cursor:
+			line = fmt.Sprintf("%s%s\n      %s", cursor, title, desc)
+		default:
+			line = fmt.Sprintf("%s%s", cursor, title)
+		}
+		// cała linijka klikalna, nie tylko tytuł
+		b.WriteString(zone(fmt.Sprintf("home.%d", i), line))
+		b.WriteString("\n")
 	}
 
 	// help + chaos
 	b.WriteString("\n")
-	helpText := "Use ↑/↓ or j/k to navigate • Enter to select • Press q to quit"
-	b.WriteString(HelpStyle.Render(helpText))
+	b.WriteString(keyHelp(m.theme, m.tr, m.width, m.KeyMap()))
+	b.WriteString("\n")
+	b.WriteString(m.theme.Help.UnsetMarginTop().Render(m.tr.T("home.theme", m.theme.Name())))
 
 	if m.secretUnlocked {
 		b.WriteString("\n")
-		secretLine := fmt.Sprintf("bonus: type 'snake' albo 'games' kiedyś tam. %s", m.secretMessage)
-		b.WriteString(HelpStyle.Render(secretLine))
+		secretLine := m.tr.T("home.bonus", m.tr.T(m.secretMessage))
+		b.WriteString(m.theme.RenderHelp(m.width, secretLine))
 	}
 
-	return BaseStyle.Render(b.String())
+	return m.theme.Base.Render(b.String())
 }
 
-func markSecretTitle(item MenuItem) string {
+// KeyMap to klawisze home, do helpa na dole i pod ?
+func (m HomeModel) KeyMap() help.KeyMap {
+	return homeKeys
+}
+
+func (m HomeModel) markSecretTitle(item MenuItem) string {
 	if item.isSecret {
-		return item.Title + " *"
+		return m.itemTitle(item) + " *"
+	}
+	return m.itemTitle(item)
+}
+
+// itemTitle bez gwiazdki, Exit nie jest ekranem więc z katalogu
+func (m HomeModel) itemTitle(item MenuItem) string {
+	if item.Target == ViewExit {
+		return m.tr.T("menu.exit.title")
 	}
-	return item.Title
+	if s, ok := m.dir.Find(item.Target); ok {
+		return s.Title()
+	}
+	return string(item.Target)
+}
+
+// itemDescription tak samo
+func (m HomeModel) itemDescription(item MenuItem) string {
+	if item.Target == ViewExit {
+		return m.tr.T("menu.exit.desc")
+	}
+	if s, ok := m.dir.Find(item.Target); ok {
+		return s.Description()
+	}
+	return ""
+}
+
+// homeBanner rysuje ramkę, subtitle wycentrowany bo tłumaczenia mają różne długości.
+// Glyphy z theme, więc na vt100 wychodzi zwykłe +-|
+func homeBanner(g Glyphs, subtitle string) string {
+	const inner = 39
+	pad := inner - lipgloss.Width(subtitle)
+	if pad < 0 {
+		pad = 0
+	}
+	centered := strings.Repeat(" ", pad-pad/2) + subtitle + strings.Repeat(" ", pad/2)
+
+	return "\n" + g.frame([]string{
+		"",
+		"         P C S T Y L E . D E V         ",
+		"",
+		centered,
+		"",
+	}, inner) + "\n"
 }
@@ -0,0 +1,326 @@
+package ui
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pcstyle/ssh-server/internal/api"
+)
+
+var htmlAttr = regexp.MustCompile(`([a-zA-Z-]+)\s*=\s*("[^"]*"|'[^']*'|[^\s"'>]+)`)
+
+// htmlToMarkdown turns the HTML of a feed post into the Markdown subset
+// renderMarkdown draws. It's a forgiving tag scanner, not a real parser:
+// feeds only carry simple article markup. Relative links are resolved
+// against base.
+func htmlToMarkdown(src, base string) string {
+	baseURL, _ := url.Parse(base)
+	w := &htmlWriter{}
+	var links []string
+
+	for len(src) > 0 {
+		lt := strings.IndexByte(src, '<')
+		if lt < 0 {
+			w.text(html.UnescapeString(src))
+			break
+		}
+		if lt > 0 {
+			w.text(html.UnescapeString(src[:lt]))
+			src = src[lt:]
+		}
+
+		if strings.HasPrefix(src, "<!--") {
+			end := strings.Index(src, "-->")
+			if end < 0 {
+				break
+			}
+			src = src[end+3:]
+			continue
+		}
+
+		gt := strings.IndexByte(src, '>')
+		if gt < 0 {
+			// Not a tag after all
+			w.text(html.UnescapeString(src))
+			break
+		}
+		tag := src[1:gt]
+		src = src[gt+1:]
+
+		closing := strings.HasPrefix(tag, "/")
+		name := strings.ToLower(strings.TrimPrefix(tag, "/"))
+		if i := strings.IndexFunc(name, func(r rune) bool { return unicode.IsSpace(r) || r == '/' }); i >= 0 {
+			name = name[:i]
+		}
+
+		switch name {
+		case "script", "style":
+			// Never shown, skip to the matching close tag
+			if !closing {
+				if end := strings.Index(strings.ToLower(src), "</"+name); end >= 0 {
+					src = src[end:]
+				} else {
+					src = ""
+				}
+			}
+
+		case "p", "div", "section", "article", "header", "footer", "figure", "figcaption", "table", "tr":
+			w.block(2)
+
+		case "br":
+			w.lineBreak()
+
+		case "hr":
+			w.block(2)
+			w.write("---")
+			w.block(2)
+
+		case "h1", "h2", "h3", "h4", "h5", "h6":
+			w.block(2)
+			if !closing {
+				// Headings deeper than ### look the same anyway
+				level := min(int(name[1]-'0'), 3)
+				w.open(strings.Repeat("#", level) + " ")
+			}
+
+		case "ul", "ol":
+			if closing {
+				if len(w.lists) > 0 {
+					w.lists = w.lists[:len(w.lists)-1]
+				}
+				w.block(2)
+			} else {
+				w.block(2)
+				w.lists = append(w.lists, listState{ordered: name == "ol"})
+			}
+
+		case "li":
+			if closing {
+				break
+			}
+			w.block(1)
+			marker := "- "
+			if n := len(w.lists); n > 0 && w.lists[n-1].ordered {
+				w.lists[n-1].count++
+				marker = strconv.Itoa(w.lists[n-1].count) + ". "
+			}
+			w.open(marker)
+
+		case "blockquote":
+			w.block(2)
+			if closing {
+				w.quote = max(w.quote-1, 0)
+			} else {
+				w.quote++
+			}
+
+		case "pre":
+			if closing {
+				w.endPre()
+			} else {
+				w.startPre()
+			}
+
+		case "code", "tt", "kbd":
+			if !w.pre {
+				w.inline(closing, "`")
+			}
+
+		case "strong", "b":
+			w.inline(closing, "**")
+
+		case "em", "i":
+			w.inline(closing, "*")
+
+		case "a":
+			if closing {
+				if n := len(links); n > 0 {
+					if href := links[n-1]; href != "" {
+						w.close("](" + href + ")")
+					}
+					links = links[:n-1]
+				}
+				break
+			}
+			href := resolveLink(baseURL, htmlAttrs(tag)["href"])
+			links = append(links, href)
+			if href != "" {
+				w.open("[")
+			}
+
+		case "img":
+			attrs := htmlAttrs(tag)
+			alt := strings.TrimSpace(attrs["alt"])
+			if alt == "" {
+				alt = "image"
+			}
+			if src := resolveLink(baseURL, attrs["src"]); src != "" {
+				w.write("[" + alt + "](" + src + ")")
+			}
+		}
+	}
+	if w.pre {
+		w.endPre()
+	}
+
+	return strings.TrimSpace(w.b.String())
+}
+
+type listState struct {
+	ordered bool
+	count   int
+}
+
+// htmlWriter collapses whitespace the way a browser would and keeps track
+// of where Markdown line breaks and prefixes go
+type htmlWriter struct {
+	b      strings.Builder
+	breaks int  // newlines owed before the next output
+	space  bool // a space is owed before the next word
+	glue   bool // the last output was an opening marker, no space after it
+	quote  int
+	lists  []listState
+	pre    bool
+	code   strings.Builder
+}
+
+func (w *htmlWriter) block(n int) {
+	if w.pre {
+		return
+	}
+	w.breaks = max(w.breaks, n)
+	w.space = false
+}
+
+// lineBreak is a Markdown hard break inside a paragraph
+func (w *htmlWriter) lineBreak() {
+	if w.pre {
+		w.code.WriteString("\n")
+		return
+	}
+	if w.b.Len() > 0 && w.breaks == 0 {
+		w.b.WriteString("\\")
+		w.breaks = 1
+	}
+	w.space = false
+}
+
+// write emits s after any owed line breaks or space
+func (w *htmlWriter) write(s string) {
+	switch {
+	case w.b.Len() == 0:
+		w.b.WriteString(w.prefix())
+	case w.breaks > 0:
+		w.b.WriteString(strings.Repeat("\n", w.breaks))
+		w.b.WriteString(w.prefix())
+	case w.space && !w.glue:
+		w.b.WriteString(" ")
+	}
+	w.breaks, w.space, w.glue = 0, false, false
+	w.b.WriteString(s)
+}
+
+func (w *htmlWriter) prefix() string {
+	if w.quote > 0 {
+		return "> "
+	}
+	return ""
+}
+
+// open writes a marker that sticks to the word after it
+func (w *htmlWriter) open(marker string) {
+	w.write(marker)
+	w.glue = true
+}
+
+// close writes a marker that sticks to the word before it
+func (w *htmlWriter) close(marker string) {
+	if w.b.Len() == 0 || w.breaks > 0 {
+		return
+	}
+	w.b.WriteString(marker)
+	w.glue = false
+}
+
+func (w *htmlWriter) inline(closing bool, marker string) {
+	if closing {
+		w.close(marker)
+	} else {
+		w.open(marker)
+	}
+}
+
+// text writes decoded text. Entities can spell out any character, escape
+// sequences included, so control characters are dropped here.
+func (w *htmlWriter) text(s string) {
+	s = api.StripControls(s)
+	if w.pre {
+		w.code.WriteString(s)
+		return
+	}
+	if s == "" {
+		return
+	}
+
+	if r, _ := utf8.DecodeRuneInString(s); unicode.IsSpace(r) {
+		w.space = true
+	}
+	for i, word := range strings.Fields(s) {
+		if i > 0 {
+			w.space = true
+		}
+		w.write(word)
+	}
+	if r, _ := utf8.DecodeLastRuneInString(s); unicode.IsSpace(r) {
+		w.space = true
+	}
+}
+
+func (w *htmlWriter) startPre() {
+	w.block(2)
+	w.write("```")
+	w.pre = true
+	w.code.Reset()
+}
+
+func (w *htmlWriter) endPre() {
+	code := strings.Trim(w.code.String(), "\n")
+	w.pre = false
+	w.b.WriteString("\n" + code + "\n```")
+	w.breaks = 2
+}
+
+// htmlAttrs reads the attributes of a tag, values unescaped
+func htmlAttrs(tag string) map[string]string {
+	attrs := make(map[string]string)
+	for _, m := range htmlAttr.FindAllStringSubmatch(tag, -1) {
+		attrs[strings.ToLower(m[1])] = api.StripControls(html.UnescapeString(strings.Trim(m[2], `"'`)))
+	}
+	return attrs
+}
+
+// resolveLink makes href absolute and drops anything that isn't a web or
+// mail link
+func resolveLink(base *url.URL, href string) string {
+	href = strings.TrimSpace(href)
+	if href == "" || strings.HasPrefix(href, "#") {
+		return ""
+	}
+	u, err := url.Parse(href)
+	if err != nil {
+		return ""
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	switch u.Scheme {
+	case "http", "https", "mailto":
+		return u.String()
+	}
+	return ""
+}
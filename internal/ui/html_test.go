@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pcstyle/ssh-server/internal/api"
+)
+
+// An entity in a remote post must not become an escape sequence on the
+// visitor's terminal: this one would write their clipboard (OSC 52)
+const oscPayload = "&#27;]52;c;ZXZpbA==&#7;"
+
+func TestPostsCannotEscape(t *testing.T) {
+	theme := NewTheme(lipgloss.NewRenderer(io.Discard), nil, Capabilities{})
+	for _, src := range []string{
+		"<p>hi " + oscPayload + " there</p>",
+		"<pre>hi " + oscPayload + "\n&#155;2J there</pre>",
+		`<p><img alt="hi ` + oscPayload + `" src="/a.png"></p>`,
+	} {
+		out := renderMarkdown(htmlToMarkdown(src, "https://example.com/"), theme, 60)
+		if strings.ContainsAny(out, "\x1b\a\u009b") {
+			t.Errorf("%q rendered as %q", src, out)
+		}
+		if !strings.Contains(out, "hi") {
+			t.Errorf("%q lost its text: %q", src, out)
+		}
+	}
+
+	// XML has no room for ESC, but C1 controls like CSI are valid in it
+	feed, err := api.ParseFeed([]byte(`<rss><channel><title>blog` + "\u009b2J" + `</title><item>
+		<title>hi ` + "\u009b2J" + `</title>
+		<link>https://example.com/` + "\u009b" + `post</link>
+	</item></channel></rss>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	post := feed.Posts[0]
+	for _, s := range []string{feed.Title, post.Title, post.Link} {
+		if strings.ContainsAny(s, "\x1b\a\u009b") {
+			t.Errorf("control characters kept in %q", s)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Locale identifies a message catalog
+type Locale string
+
+const (
+	LocaleEN Locale = "en"
+	LocalePL Locale = "pl"
+)
+
+// locales lists the supported catalogs in toggle order
+var locales = []Locale{LocaleEN, LocalePL}
+
+// catalogs maps each locale to its messages; English is the fallback
+var catalogs = map[Locale]map[string]string{
+	LocaleEN: catalogEN,
+	LocalePL: catalogPL,
+}
+
+// Translator looks up UI strings in the session's current locale
+type Translator struct {
+	locale Locale
+}
+
+// NewTranslator creates a translator for locale
+func NewTranslator(locale Locale) *Translator {
+	if _, ok := catalogs[locale]; !ok {
+		locale = LocaleEN
+	}
+	return &Translator{locale: locale}
+}
+
+// Locale returns the active locale
+func (t *Translator) Locale() Locale {
+	if t == nil {
+		return LocaleEN
+	}
+	return t.locale
+}
+
+// Toggle switches to the next supported locale
+func (t *Translator) Toggle() {
+	for i, l := range locales {
+		if l == t.locale {
+			t.locale = locales[(i+1)%len(locales)]
+			return
+		}
+	}
+	t.locale = LocaleEN
+}
+
+// T returns the message for key, formatted with args. Keys missing from the
+// active catalog fall back to English, and unknown keys are returned as-is.
+func (t *Translator) T(key string, args ...any) string {
+	msg, ok := catalogs[t.Locale()][key]
+	if !ok {
+		if msg, ok = catalogEN[key]; !ok {
+			msg = key
+		}
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// DetectLocale picks a locale from the client's environment as sent over SSH.
+// LC_ALL wins over LC_MESSAGES, which wins over LANG, like in POSIX.
+func DetectLocale(env []string) Locale {
+	vars := envMap(env)
+
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := vars[name]
+		if value == "" {
+			continue
+		}
+
+		// pl_PL.UTF-8 -> pl
+		lang := strings.ToLower(value)
+		if i := strings.IndexAny(lang, "_.@-"); i >= 0 {
+			lang = lang[:i]
+		}
+		if _, ok := catalogs[Locale(lang)]; ok {
+			return Locale(lang)
+		}
+		return LocaleEN
+	}
+
+	return LocaleEN
+}
@@ -0,0 +1,347 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pcstyle/ssh-server/internal/api"
+	"github.com/pcstyle/ssh-server/internal/store"
+)
+
+// InboxModel lists a visitor's conversations and the owner's replies
+type InboxModel struct {
+	session    Session
+	apiClient  *api.Client
+	threads    []store.Thread
+	cursor     int
+	open       bool
+	composing  bool
+	sending    bool
+	reply      textinput.Model
+	statusLine string
+	width      int
+	height     int
+	tr         *Translator
+	theme      *Theme
+}
+
+// followUpResultMsg is sent when a follow-up message has been delivered
+type followUpResultMsg struct {
+	reference string
+	err       error
+}
+
+func init() {
+	RegisterScreen("inbox", 80, func(env ScreenEnv) []Screen {
+		return []Screen{NewInboxModel(env.API, env.Session, env.Tr, env.Theme)}
+	})
+}
+
+// NewInboxModel creates the inbox view for a session
+func NewInboxModel(apiClient *api.Client, session Session, tr *Translator, theme *Theme) InboxModel {
+	reply := textinput.New()
+	reply.CharLimit = maxMessageLen - utf8.RuneCountInString(followUpMessage(api.ReferenceID(0), ""))
+	reply.Width = 60
+
+	return InboxModel{
+		session:   session,
+		apiClient: apiClient,
+		reply:     reply,
+		tr:        tr,
+		theme:     theme,
+	}
+}
+
+// ID identifies the inbox
+func (m InboxModel) ID() View {
+	return ViewInbox
+}
+
+// Title is the menu entry
+func (m InboxModel) Title() string {
+	return m.tr.T("menu.inbox.title")
+}
+
+// Description is shown next to the menu entry
+func (m InboxModel) Description() string {
+	return m.tr.T("menu.inbox.desc")
+}
+
+// Hidden keeps the inbox out of the menu until the visitor has a
+// conversation. It asks the store every time, so sending a message from
+// the contact form puts the inbox in the menu straight away.
+func (m InboxModel) Hidden() bool {
+	return m.session.Fingerprint == "" || m.session.Inbox == nil ||
+		len(m.session.Inbox.Threads(m.session.Fingerprint)) == 0
+}
+
+// Unlock is empty, no word opens the inbox
+func (m InboxModel) Unlock() []string {
+	return nil
+}
+
+// Init has nothing to do, threads load on Enter
+func (m InboxModel) Init() tea.Cmd {
+	return nil
+}
+
+// Enter reloads threads from the store. A fresh visit starts at the list,
+// one from history keeps the open thread and any reply being typed.
+func (m InboxModel) Enter(how Arrival) (Screen, tea.Cmd) {
+	m.threads = m.session.Inbox.Threads(m.session.Fingerprint)
+	if m.cursor >= len(m.threads) {
+		m.cursor = 0
+		m.open = false
+		m.composing = false
+	}
+	if how == ArriveNew {
+		m.open = false
+		m.composing = false
+		m.statusLine = ""
+	}
+	return m, nil
+}
+
+// Leave has nothing to do, replies being sent report back wherever we are
+func (m InboxModel) Leave() (Screen, tea.Cmd) {
+	return m, nil
+}
+
+// crumb is the subject of the open thread
+func (m InboxModel) crumb() string {
+	if !m.open || m.cursor >= len(m.threads) {
+		return ""
+	}
+	return m.threads[m.cursor].Subject
+}
+
+// Update handles list, thread and follow-up keys
+func (m InboxModel) Update(msg tea.Msg) (Screen, tea.Cmd) {
+	switch typed := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = typed.Width
+		m.height = typed.Height
+		m.reply.Width = clamp(typed.Width-16, 20, 60)
+
+	case followUpResultMsg:
+		m.sending = false
+		if typed.err != nil {
+			m.statusLine = "✗ " + typed.err.Error()
+			return m, nil
+		}
+		m.composing = false
+		m.reply.SetValue("")
+		m.reply.Blur()
+		m.statusLine = "✓ " + m.tr.T("inbox.sent")
+		m.threads = m.session.Inbox.Threads(m.session.Fingerprint)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch {
+		case m.composing:
+			return m.updateCompose(typed)
+		case m.open:
+			return m.updateThread(typed)
+		default:
+			return m.updateList(typed)
+		}
+	}
+
+	return m, nil
+}
+
+func (m InboxModel) updateList(msg tea.KeyMsg) (InboxModel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, inboxKeys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(msg, inboxKeys.Down):
+		if m.cursor < len(m.threads)-1 {
+			m.cursor++
+		}
+	case key.Matches(msg, inboxKeys.Open):
+		if len(m.threads) == 0 {
+			return m, nil
+		}
+		m.open = true
+		m.statusLine = ""
+		thread := &m.threads[m.cursor]
+		if thread.Unread > 0 {
+			thread.Unread = 0
+			fingerprint, reference := m.session.Fingerprint, thread.Reference
+			inbox := m.session.Inbox
+			return m, func() tea.Msg {
+				inbox.MarkRead(fingerprint, reference)
+				return nil
+			}
+		}
+	case key.Matches(msg, inboxKeys.Back):
+		return m, func() tea.Msg { return BackMsg{} }
+	}
+	return m, nil
+}
+
+func (m InboxModel) updateThread(msg tea.KeyMsg) (InboxModel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, threadKeys.Reply):
+		m.composing = true
+		m.statusLine = ""
+		return m, m.reply.Focus()
+	case key.Matches(msg, threadKeys.Back):
+		m.open = false
+		m.statusLine = ""
+	}
+	return m, nil
+}
+
+func (m InboxModel) updateCompose(msg tea.KeyMsg) (InboxModel, tea.Cmd) {
+	if m.sending {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, composeKeys.Cancel):
+		m.composing = false
+		m.reply.Blur()
+		return m, nil
+	case key.Matches(msg, composeKeys.Send):
+		body := strings.TrimSpace(m.reply.Value())
+		reference := m.threads[m.cursor].Reference
+		if err := validateMessage(followUpMessage(reference, ""), body); err != nil {
+			m.statusLine = "✗ " + localizeError(m.tr, err)
+			return m, nil
+		}
+		m.sending = true
+		m.statusLine = m.tr.T("contact.sending")
+		return m, m.sendFollowUp(reference, body)
+	}
+
+	var cmd tea.Cmd
+	m.reply, cmd = m.reply.Update(msg)
+	return m, cmd
+}
+
+// KeyMap is what works on the list, in a thread or while replying
+func (m InboxModel) KeyMap() help.KeyMap {
+	switch {
+	case m.composing:
+		return composeKeys
+	case m.open:
+		return threadKeys
+	}
+	keys := inboxKeys
+	keys.Up.SetEnabled(len(m.threads) > 1)
+	keys.Down.SetEnabled(len(m.threads) > 1)
+	keys.Open.SetEnabled(len(m.threads) > 0)
+	return keys
+}
+
+// typing reports whether keys go into the reply box
+func (m InboxModel) typing() bool {
+	return m.composing
+}
+
+// sendFollowUp posts the follow-up through the contact API so the owner is
+// notified, then appends it to the local thread
+func (m InboxModel) sendFollowUp(reference, body string) tea.Cmd {
+	client, inbox := m.apiClient, m.session.Inbox
+	return func() tea.Msg {
+		req := api.ContactRequest{
+			Message: followUpMessage(reference, body),
+		}
+		if _, err := client.SubmitContact(req); err != nil {
+			return followUpResultMsg{reference: reference, err: err}
+		}
+		return followUpResultMsg{reference: reference, err: inbox.FollowUp(reference, body)}
+	}
+}
+
+// followUpMessage is what the owner gets for a follow-up, the reference up
+// front so it can be matched to the thread
+func followUpMessage(reference, body string) string {
+	return fmt.Sprintf("[re %s] %s", reference, body)
+}
+
+// View renders either the thread list or a single conversation
+func (m InboxModel) View() string {
+	if m.open && m.cursor < len(m.threads) {
+		return m.threadView(m.threads[m.cursor])
+	}
+
+	var b strings.Builder
+	b.WriteString(m.theme.Title.Render(m.tr.T("menu.inbox.title")))
+	b.WriteString("\n\n")
+
+	if len(m.threads) == 0 {
+		b.WriteString(m.theme.RenderHelp(m.width, m.tr.T("inbox.empty")))
+		b.WriteString("\n")
+	}
+
+	for i, t := range m.threads {
+		cursor := "  "
+		itemStyle := m.theme.NavItem
+		if i == m.cursor {
+			cursor = m.theme.NavArrow.Render(m.theme.Glyphs.Arrow + " ")
+			itemStyle = m.theme.NavItemSelected
+		}
+
+		badge := ""
+		if t.Unread > 0 {
+			badge = m.theme.NavArrow.Render(" " + m.theme.Glyphs.Dot + " " + m.tr.T("inbox.new", t.Unread))
+		}
+		meta := m.theme.Help.UnsetMarginTop().Render(fmt.Sprintf("%s • %s", t.Reference, t.UpdatedAt.Format("2006-01-02 15:04")))
+		b.WriteString(fmt.Sprintf("%s%s%s - %s\n", cursor, itemStyle.Render(t.Subject), badge, meta))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(keyHelp(m.theme, m.tr, m.width, m.KeyMap()))
+
+	return m.theme.BoxFor(m.width).Render(b.String())
+}
+
+func (m InboxModel) threadView(t store.Thread) string {
+	var b strings.Builder
+	b.WriteString(m.theme.Title.Render(t.Subject))
+	b.WriteString("\n")
+	b.WriteString(m.theme.RenderHelp(m.width, t.Reference))
+	b.WriteString("\n\n")
+
+	for _, msg := range t.Messages {
+		author := m.tr.T("inbox.you")
+		style := m.theme.NavItem
+		if msg.From == store.FromOwner {
+			author = "pcstyle"
+			style = m.theme.NavItemSelected
+		}
+		b.WriteString(m.theme.Label.Render(author))
+		b.WriteString(m.theme.Help.UnsetMarginTop().Render(msg.At.Format("2006-01-02 15:04")))
+		b.WriteString("\n")
+		if compact(m.width) {
+			style = style.Width(m.width - 8)
+		}
+		b.WriteString(style.Render(msg.Body))
+		b.WriteString("\n\n")
+	}
+
+	if m.composing {
+		reply := m.reply
+		reply.Placeholder = m.tr.T("inbox.placeholder")
+		b.WriteString(m.theme.InputFocused.Render(reply.View()))
+		b.WriteString("\n")
+	}
+
+	if m.statusLine != "" {
+		b.WriteString(m.theme.RenderHelp(m.width, m.statusLine))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(keyHelp(m.theme, m.tr, m.width, m.KeyMap()))
+
+	return m.theme.BoxFor(m.width).Render(b.String())
+}
@@ -0,0 +1,762 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Keymaps for every view and stage. Help descriptions are catalog keys,
+// translated when the help is rendered so toggling the language applies
+// right away. The maps are shared by all sessions and never changed in
+// place; views that hide bindings depending on state do it on a copy.
+//
+// The same rules hold everywhere: esc and q go back one level (q quits on
+// the home screen), ctrl+c quits from anywhere, ? shows every key and
+// ctrl+k or : opens the command palette and alt+←/→ go back and forward
+// through the screens visited. While typing, q, ? and : are just text and
+// alt+←/→ jump words; ctrl+k still opens the palette.
+
+// globalKeyMap is handled by the app before any view sees the key
+type globalKeyMap struct {
+	Help     key.Binding
+	Palette  key.Binding
+	Backward key.Binding
+	Forward  key.Binding
+	Quit     key.Binding
+}
+
+var globalKeys = globalKeyMap{
+	Help:     key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "keys.help")),
+	Palette:  key.NewBinding(key.WithKeys("ctrl+k", ":"), key.WithHelp("ctrl+k/:", "keys.palette")),
+	Backward: key.NewBinding(key.WithKeys("alt+left"), key.WithHelp("alt+←", "keys.backward")),
+	Forward:  key.NewBinding(key.WithKeys("alt+right"), key.WithHelp("alt+→", "keys.forward")),
+	Quit:     key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", "keys.quit")),
+}
+
+func (k globalKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Help, k.Palette, k.Backward, k.Forward, k.Quit}
+}
+
+func (k globalKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
+
+// newScrollKeys is the viewport keymap with translated help. Horizontal
+// scrolling is left unbound so views can use ←/→ for their own things.
+func newScrollKeys() viewport.KeyMap {
+	return viewport.KeyMap{
+		Up:           key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "keys.up")),
+		Down:         key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "keys.down")),
+		PageUp:       key.NewBinding(key.WithKeys("pgup", "b"), key.WithHelp("pgup/b", "keys.page_up")),
+		PageDown:     key.NewBinding(key.WithKeys("pgdown", " ", "f"), key.WithHelp("pgdn/space", "keys.page_down")),
+		HalfPageUp:   key.NewBinding(key.WithKeys("u", "ctrl+u"), key.WithHelp("u", "keys.half_up")),
+		HalfPageDown: key.NewBinding(key.WithKeys("d", "ctrl+d"), key.WithHelp("d", "keys.half_down")),
+	}
+}
+
+func scrollHelp(k viewport.KeyMap) []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.PageUp, k.PageDown, k.HalfPageUp, k.HalfPageDown}
+}
+
+// Shared bindings, so the same action has the same keys in every view. q
+// goes back too, except on home where it quits and on a page or the contact
+// receipt where it shows the QR code; esc is back there
+var (
+	upKey   = key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "keys.up"))
+	downKey = key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "keys.down"))
+	openKey = key.NewBinding(key.WithKeys("enter", " "), key.WithHelp("enter", "keys.open"))
+	backKey = key.NewBinding(key.WithKeys("esc", "q"), key.WithHelp("esc/q", "keys.back"))
+)
+
+// Home ----------------------------------------------------------------------
+
+type homeKeyMap struct {
+	Up       key.Binding
+	Down     key.Binding
+	Select   key.Binding
+	Language key.Binding
+	Theme    key.Binding
+	Quit     key.Binding
+}
+
+var homeKeys = homeKeyMap{
+	Up:       upKey,
+	Down:     downKey,
+	Select:   key.NewBinding(key.WithKeys("enter", " "), key.WithHelp("enter", "keys.select")),
+	Language: key.NewBinding(key.WithKeys("l"), key.WithHelp("l", "keys.language")),
+	Theme:    key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "keys.theme")),
+	Quit:     key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "keys.quit")),
+}
+
+func (k homeKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Select, k.Language, k.Quit, globalKeys.Help}
+}
+
+func (k homeKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down, k.Select}, {k.Language, k.Theme, k.Quit}}
+}
+
+// Content pages -------------------------------------------------------------
+
+type pageKeyMap struct {
+	Scroll   viewport.KeyMap
+	Top      key.Binding
+	Bottom   key.Binding
+	NextLink key.Binding
+	PrevLink key.Binding
+	Copy     key.Binding
+	QR       key.Binding
+	Back     key.Binding
+}
+
+var pageKeys = pageKeyMap{
+	Scroll:   newScrollKeys(),
+	Top:      key.NewBinding(key.WithKeys("home", "g"), key.WithHelp("g", "keys.top")),
+	Bottom:   key.NewBinding(key.WithKeys("end", "G"), key.WithHelp("G", "keys.bottom")),
+	NextLink: key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "keys.next_link")),
+	PrevLink: key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "keys.prev_link")),
+	Copy:     key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "keys.copy")),
+	QR:       key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "keys.qr")),
+	Back:     key.NewBinding(key.WithKeys("esc", "enter"), key.WithHelp("esc", "keys.back")),
+}
+
+func (k pageKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Scroll.Up, k.Scroll.Down, k.NextLink, k.Copy, k.QR, k.Back, globalKeys.Help}
+}
+
+func (k pageKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		append(scrollHelp(k.Scroll), k.Top, k.Bottom),
+		{k.NextLink, k.PrevLink, k.Copy, k.QR, k.Back},
+	}
+}
+
+// Blog ----------------------------------------------------------------------
+
+type blogKeyMap struct {
+	Up       key.Binding
+	Down     key.Binding
+	PrevPage key.Binding
+	NextPage key.Binding
+	Refresh  key.Binding
+	Open     key.Binding
+	Back     key.Binding
+}
+
+var blogKeys = blogKeyMap{
+	Up:       upKey,
+	Down:     downKey,
+	PrevPage: key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("←/h", "keys.prev_page")),
+	NextPage: key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("→/l", "keys.next_page")),
+	Refresh:  key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "keys.refresh")),
+	Open:     key.NewBinding(key.WithKeys("enter", " "), key.WithHelp("enter", "keys.read")),
+	Back:     backKey,
+}
+
+func (k blogKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.PrevPage, k.NextPage, k.Open, k.Refresh, k.Back, globalKeys.Help}
+}
+
+func (k blogKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down, k.PrevPage, k.NextPage}, {k.Open, k.Refresh, k.Back}}
+}
+
+// blogPostKeyMap pages with ←/→ too, on top of the usual scroll keys
+type blogPostKeyMap struct {
+	Scroll viewport.KeyMap
+	Top    key.Binding
+	Bottom key.Binding
+	Close  key.Binding
+}
+
+var blogPostKeys = func() blogPostKeyMap {
+	scroll := newScrollKeys()
+	scroll.PageUp = key.NewBinding(key.WithKeys("left", "h", "pgup", "b"), key.WithHelp("←/pgup", "keys.page_up"))
+	scroll.PageDown = key.NewBinding(key.WithKeys("right", "l", "pgdown", " ", "f"), key.WithHelp("→/pgdn", "keys.page_down"))
+	return blogPostKeyMap{
+		Scroll: scroll,
+		Top:    key.NewBinding(key.WithKeys("home", "g"), key.WithHelp("g", "keys.top")),
+		Bottom: key.NewBinding(key.WithKeys("end", "G"), key.WithHelp("G", "keys.bottom")),
+		Close:  key.NewBinding(key.WithKeys("esc", "q", "backspace"), key.WithHelp("esc/q", "keys.back_list")),
+	}
+}()
+
+func (k blogPostKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Scroll.Up, k.Scroll.Down, k.Scroll.PageUp, k.Scroll.PageDown, k.Close, globalKeys.Help}
+}
+
+func (k blogPostKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{append(scrollHelp(k.Scroll), k.Top, k.Bottom), {k.Close}}
+}
+
+// Projects ------------------------------------------------------------------
+
+type projectsKeyMap struct {
+	Up   key.Binding
+	Down key.Binding
+	Open key.Binding
+	Back key.Binding
+}
+
+var projectsKeys = projectsKeyMap{
+	Up:   upKey,
+	Down: downKey,
+	Open: key.NewBinding(key.WithKeys("enter", " "), key.WithHelp("enter", "keys.details")),
+	Back: backKey,
+}
+
+func (k projectsKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Open, k.Back, globalKeys.Help}
+}
+
+func (k projectsKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down}, {k.Open, k.Back}}
+}
+
+type projectKeyMap struct {
+	Scroll   viewport.KeyMap
+	NextLink key.Binding
+	PrevLink key.Binding
+	OpenLink key.Binding
+	Close    key.Binding
+}
+
+var projectKeys = projectKeyMap{
+	Scroll:   newScrollKeys(),
+	NextLink: key.NewBinding(key.WithKeys("tab", "right", "l"), key.WithHelp("tab/→", "keys.next_link")),
+	PrevLink: key.NewBinding(key.WithKeys("shift+tab", "left", "h"), key.WithHelp("shift+tab/←", "keys.prev_link")),
+	OpenLink: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "keys.open_link")),
+	Close:    key.NewBinding(key.WithKeys("esc", "q", "backspace"), key.WithHelp("esc/q", "keys.back_list")),
+}
+
+func (k projectKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Scroll.Up, k.Scroll.Down, k.NextLink, k.OpenLink, k.Close, globalKeys.Help}
+}
+
+func (k projectKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{scrollHelp(k.Scroll), {k.NextLink, k.PrevLink, k.OpenLink, k.Close}}
+}
+
+// Inbox ---------------------------------------------------------------------
+
+type inboxKeyMap struct {
+	Up   key.Binding
+	Down key.Binding
+	Open key.Binding
+	Back key.Binding
+}
+
+var inboxKeys = inboxKeyMap{
+	Up:   upKey,
+	Down: downKey,
+	Open: openKey,
+	Back: backKey,
+}
+
+func (k inboxKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Open, k.Back, globalKeys.Help}
+}
+
+func (k inboxKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down}, {k.Open, k.Back}}
+}
+
+type threadKeyMap struct {
+	Reply key.Binding
+	Back  key.Binding
+}
+
+var threadKeys = threadKeyMap{
+	Reply: key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "keys.reply")),
+	Back:  backKey,
+}
+
+func (k threadKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Reply, k.Back, globalKeys.Help}
+}
+
+func (k threadKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Reply, k.Back}}
+}
+
+// composeKeyMap is for typing a reply, so no ? and no q
+type composeKeyMap struct {
+	Send   key.Binding
+	Cancel key.Binding
+}
+
+var composeKeys = composeKeyMap{
+	Send:   key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "keys.send")),
+	Cancel: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "keys.cancel")),
+}
+
+func (k composeKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Send, k.Cancel}
+}
+
+func (k composeKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
+
+// Contact -------------------------------------------------------------------
+
+// contactKeyMap is for the form, where letters are text
+type contactKeyMap struct {
+	Next   key.Binding
+	Prev   key.Binding
+	Press  key.Binding
+	Resume key.Binding
+	Back   key.Binding
+}
+
+var contactKeys = contactKeyMap{
+	Next:   key.NewBinding(key.WithKeys("tab", "down"), key.WithHelp("tab/↓", "keys.next_field")),
+	Prev:   key.NewBinding(key.WithKeys("shift+tab", "up"), key.WithHelp("shift+tab/↑", "keys.prev_field")),
+	Press:  key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "keys.press")),
+	Resume: key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "keys.resume")),
+	Back:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "keys.back")),
+}
+
+func (k contactKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Next, k.Prev, k.Press, k.Resume, k.Back}
+}
+
+func (k contactKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Next, k.Prev, k.Press}, {k.Resume, k.Back}}
+}
+
+type confirmKeyMap struct {
+	Prev    key.Binding
+	Next    key.Binding
+	Edit    key.Binding
+	Confirm key.Binding
+}
+
+var confirmKeys = confirmKeyMap{
+	Prev:    key.NewBinding(key.WithKeys("left", "shift+tab", "h"), key.WithHelp("←/h", "keys.prev")),
+	Next:    key.NewBinding(key.WithKeys("right", "tab", "l"), key.WithHelp("→/l", "keys.next")),
+	Edit:    key.NewBinding(key.WithKeys("e", "esc"), key.WithHelp("e/esc", "keys.edit")),
+	Confirm: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "keys.confirm")),
+}
+
+func (k confirmKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Prev, k.Next, k.Confirm, k.Edit, globalKeys.Help}
+}
+
+func (k confirmKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Prev, k.Next}, {k.Confirm, k.Edit}}
+}
+
+type receiptKeyMap struct {
+	QR   key.Binding
+	Back key.Binding
+}
+
+var receiptKeys = receiptKeyMap{
+	QR:   key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "keys.qr_card")),
+	Back: key.NewBinding(key.WithKeys("enter", "esc"), key.WithHelp("enter/esc", "keys.back")),
+}
+
+func (k receiptKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.QR, k.Back, globalKeys.Help}
+}
+
+func (k receiptKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.QR, k.Back}}
+}
+
+type restoreKeyMap struct {
+	Restore key.Binding
+	Discard key.Binding
+	Back    key.Binding
+}
+
+var restoreKeys = restoreKeyMap{
+	Restore: key.NewBinding(key.WithKeys("y", "enter"), key.WithHelp("y/enter", "keys.restore")),
+	Discard: key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "keys.discard")),
+	Back:    key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "keys.back")),
+}
+
+func (k restoreKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Restore, k.Discard, k.Back, globalKeys.Help}
+}
+
+func (k restoreKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Restore, k.Discard, k.Back}}
+}
+
+// resumeKeyMap is for typing a resume code
+type resumeKeyMap struct {
+	Lookup key.Binding
+	Cancel key.Binding
+}
+
+var resumeKeys = resumeKeyMap{
+	Lookup: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "keys.lookup")),
+	Cancel: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "keys.cancel")),
+}
+
+func (k resumeKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Lookup, k.Cancel}
+}
+
+func (k resumeKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
+
+// Arcade and secrets --------------------------------------------------------
+
+type arcadeKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Launch key.Binding
+	Back   key.Binding
+}
+
+var arcadeKeys = arcadeKeyMap{
+	Up:     upKey,
+	Down:   downKey,
+	Launch: key.NewBinding(key.WithKeys("enter", " "), key.WithHelp("enter", "keys.launch")),
+	Back:   backKey,
+}
+
+func (k arcadeKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Launch, k.Back, globalKeys.Help}
+}
+
+func (k arcadeKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down}, {k.Launch, k.Back}}
+}
+
+// snakeKeyMap steers with arrows, hjkl or wasd
+type snakeKeyMap struct {
+	Up        key.Binding
+	Down      key.Binding
+	Left      key.Binding
+	Right     key.Binding
+	Respawn   key.Binding
+	Pause     key.Binding
+	NextLevel key.Binding
+	Watch     key.Binding
+	Share     key.Binding
+	Leave     key.Binding
+}
+
+var snakeKeys = snakeKeyMap{
+	Up:        key.NewBinding(key.WithKeys("up", "k", "w"), key.WithHelp("↑/w", "keys.up")),
+	Down:      key.NewBinding(key.WithKeys("down", "j", "s"), key.WithHelp("↓/s", "keys.down")),
+	Left:      key.NewBinding(key.WithKeys("left", "h", "a"), key.WithHelp("←/a", "keys.left")),
+	Right:     key.NewBinding(key.WithKeys("right", "l", "d"), key.WithHelp("→/d", "keys.right")),
+	Respawn:   key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "keys.respawn")),
+	Pause:     key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "keys.pause")),
+	NextLevel: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "keys.next_level")),
+	Watch:     key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "keys.watch_replay")),
+	Share:     key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "keys.share_replay")),
+	Leave:     backKey,
+}
+
+func (k snakeKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Watch, k.Share, k.Up, k.Down, k.Left, k.Right, k.Pause, k.Respawn, k.Leave, globalKeys.Help}
+}
+
+func (k snakeKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down, k.Left, k.Right}, {k.Pause, k.NextLevel, k.Respawn, k.Leave}, {k.Watch, k.Share}}
+}
+
+// replayKeyMap controls playback of a recorded snake game
+type replayKeyMap struct {
+	Slower  key.Binding
+	Faster  key.Binding
+	Pause   key.Binding
+	Restart key.Binding
+	Leave   key.Binding
+}
+
+var replayKeys = replayKeyMap{
+	Slower:  key.NewBinding(key.WithKeys("left", "h", "-"), key.WithHelp("←/-", "keys.slower")),
+	Faster:  key.NewBinding(key.WithKeys("right", "l", "+", "="), key.WithHelp("→/+", "keys.faster")),
+	Pause:   key.NewBinding(key.WithKeys("p", " "), key.WithHelp("p", "keys.pause")),
+	Restart: key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "keys.restart")),
+	Leave:   backKey,
+}
+
+func (k replayKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Slower, k.Faster, k.Pause, k.Restart, k.Leave, globalKeys.Help}
+}
+
+func (k replayKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Slower, k.Faster}, {k.Pause, k.Restart, k.Leave}}
+}
+
+// replayLoadKeyMap takes a pasted replay code; everything else is typed
+type replayLoadKeyMap struct {
+	Load   key.Binding
+	Cancel key.Binding
+}
+
+var replayLoadKeys = replayLoadKeyMap{
+	Load:   key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "keys.load")),
+	Cancel: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "keys.cancel")),
+}
+
+func (k replayLoadKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Load, k.Cancel}
+}
+
+func (k replayLoadKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
+
+// snakeSetupKeyMap picks the difficulty and walls before a game
+type snakeSetupKeyMap struct {
+	Up    key.Binding
+	Down  key.Binding
+	Prev  key.Binding
+	Next  key.Binding
+	Start key.Binding
+	Back  key.Binding
+}
+
+var snakeSetupKeys = snakeSetupKeyMap{
+	Up:    upKey,
+	Down:  downKey,
+	Prev:  key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("←/h", "keys.prev")),
+	Next:  key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("→/l", "keys.next")),
+	Start: key.NewBinding(key.WithKeys("enter", " "), key.WithHelp("enter", "keys.start")),
+	Back:  backKey,
+}
+
+func (k snakeSetupKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Prev, k.Next, k.Start, k.Back, globalKeys.Help}
+}
+
+func (k snakeSetupKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down, k.Prev, k.Next}, {k.Start, k.Back}}
+}
+
+// arenaLobbyKeyMap picks a multiplayer room
+type arenaLobbyKeyMap struct {
+	Up   key.Binding
+	Down key.Binding
+	Join key.Binding
+	Back key.Binding
+}
+
+var arenaLobbyKeys = arenaLobbyKeyMap{
+	Up:   upKey,
+	Down: downKey,
+	Join: key.NewBinding(key.WithKeys("enter", " "), key.WithHelp("enter", "keys.join")),
+	Back: backKey,
+}
+
+func (k arenaLobbyKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Join, k.Back, globalKeys.Help}
+}
+
+func (k arenaLobbyKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down}, {k.Join, k.Back}}
+}
+
+// arenaKeyMap steers in a multiplayer room like in snake; spectators only
+// get Leave
+type arenaKeyMap struct {
+	Up    key.Binding
+	Down  key.Binding
+	Left  key.Binding
+	Right key.Binding
+	Leave key.Binding
+}
+
+var arenaKeys = arenaKeyMap{
+	Up:    snakeKeys.Up,
+	Down:  snakeKeys.Down,
+	Left:  snakeKeys.Left,
+	Right: snakeKeys.Right,
+	Leave: backKey,
+}
+
+func (k arenaKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Left, k.Right, k.Leave, globalKeys.Help}
+}
+
+func (k arenaKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down, k.Left, k.Right}, {k.Leave}}
+}
+
+type crtKeyMap struct {
+	Leave key.Binding
+}
+
+var crtKeys = crtKeyMap{
+	Leave: key.NewBinding(key.WithKeys("esc", "q", "enter"), key.WithHelp("esc/enter", "keys.crt_off")),
+}
+
+func (k crtKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Leave, globalKeys.Help}
+}
+
+func (k crtKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Leave}}
+}
+
+// initialsKeyMap spins letters with the arrows only, everything else is typed
+type initialsKeyMap struct {
+	Up    key.Binding
+	Down  key.Binding
+	Left  key.Binding
+	Right key.Binding
+	Save  key.Binding
+	Skip  key.Binding
+}
+
+var initialsKeys = initialsKeyMap{
+	Up:    key.NewBinding(key.WithKeys("up"), key.WithHelp("↑", "keys.letter_prev")),
+	Down:  key.NewBinding(key.WithKeys("down"), key.WithHelp("↓", "keys.letter_next")),
+	Left:  key.NewBinding(key.WithKeys("left"), key.WithHelp("←", "keys.prev")),
+	Right: key.NewBinding(key.WithKeys("right"), key.WithHelp("→", "keys.next")),
+	Save:  key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "keys.save")),
+	Skip:  key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "keys.skip")),
+}
+
+func (k initialsKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Left, k.Right, k.Save, k.Skip}
+}
+
+func (k initialsKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down, k.Left, k.Right}, {k.Save, k.Skip}}
+}
+
+// scoresKeyMap flips between the all-time, weekly and daily boards, and
+// between the snake modes
+type scoresKeyMap struct {
+	PrevMode key.Binding
+	NextMode key.Binding
+	Prev     key.Binding
+	Next     key.Binding
+	Back     key.Binding
+}
+
+var scoresKeys = scoresKeyMap{
+	PrevMode: key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "keys.prev_mode")),
+	NextMode: key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "keys.next_mode")),
+	Prev:     key.NewBinding(key.WithKeys("left", "h", "shift+tab"), key.WithHelp("←/h", "keys.prev")),
+	Next:     key.NewBinding(key.WithKeys("right", "l", "tab"), key.WithHelp("→/tab", "keys.next")),
+	Back:     backKey,
+}
+
+func (k scoresKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.PrevMode, k.NextMode, k.Prev, k.Next, k.Back, globalKeys.Help}
+}
+
+func (k scoresKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.PrevMode, k.NextMode}, {k.Prev, k.Next}, {k.Back}}
+}
+
+// secretsKeyMap goes back with left or up and forward with right or down
+type secretsKeyMap struct {
+	Prev key.Binding
+	Next key.Binding
+	Skip key.Binding
+	Back key.Binding
+}
+
+var secretsKeys = secretsKeyMap{
+	Prev: key.NewBinding(key.WithKeys("left", "h", "up", "k"), key.WithHelp("←/h", "keys.prev")),
+	Next: key.NewBinding(key.WithKeys("right", "l", "down", "j", " "), key.WithHelp("→/l", "keys.next")),
+	Skip: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "keys.skip")),
+	Back: backKey,
+}
+
+func (k secretsKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Prev, k.Next, k.Skip, k.Back, globalKeys.Help}
+}
+
+func (k secretsKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Prev, k.Next, k.Skip}, {k.Back}}
+}
+
+// Palette -------------------------------------------------------------------
+
+// paletteKeyMap leaves letters to the search box, so the list moves with
+// the arrows or the emacs style ctrl+p/ctrl+n
+type paletteKeyMap struct {
+	Up    key.Binding
+	Down  key.Binding
+	Run   key.Binding
+	Close key.Binding
+}
+
+var paletteKeys = paletteKeyMap{
+	Up:    key.NewBinding(key.WithKeys("up", "ctrl+p"), key.WithHelp("↑", "keys.up")),
+	Down:  key.NewBinding(key.WithKeys("down", "ctrl+n"), key.WithHelp("↓", "keys.down")),
+	Run:   key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "keys.go")),
+	Close: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "keys.cancel")),
+}
+
+func (k paletteKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Run, k.Close}
+}
+
+func (k paletteKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
+
+// Rendering -----------------------------------------------------------------
+
+// translateKeys copies bindings with their help descriptions translated
+func translateKeys(tr *Translator, bindings []key.Binding) []key.Binding {
+	out := make([]key.Binding, len(bindings))
+	for i, b := range bindings {
+		b.SetHelp(b.Help().Key, tr.T(b.Help().Desc))
+		out[i] = b
+	}
+	return out
+}
+
+// newHelp is a help bubble in the theme's colors, fitted to the window
+func newHelp(th *Theme, width int) help.Model {
+	h := help.New()
+	h.Styles = th.Keys
+	if width > 0 {
+		h.Width = max(width-8, 1)
+	}
+	return h
+}
+
+// keyHelp renders the short help line of km under a view, status text
+// like a score or scroll position first. Bindings that don't fit the
+// window are cut with an ellipsis; ? lists them all.
+func keyHelp(th *Theme, tr *Translator, width int, km help.KeyMap, status ...string) string {
+	h := newHelp(th, width)
+	var prefix string
+	if len(status) > 0 {
+		prefix = h.Styles.ShortDesc.Render(strings.Join(status, h.ShortSeparator)) +
+			h.Styles.ShortSeparator.Render(h.ShortSeparator)
+		if h.Width > 0 {
+			h.Width = max(h.Width-lipgloss.Width(prefix), 1)
+		}
+	}
+	bindings := translateKeys(tr, km.ShortHelp())
+	view := h.ShortHelpView(bindings)
+	// help only cuts the line where an ellipsis still fits after the last
+	// binding, otherwise it lets everything through; drop bindings by hand
+	for n := len(bindings) - 1; h.Width > 0 && n > 0 && lipgloss.Width(view) > h.Width; n-- {
+		view = h.ShortHelpView(bindings[:n]) + " " + h.Styles.Ellipsis.Render(h.Ellipsis)
+	}
+	line := prefix + view
+	return th.renderer.NewStyle().MarginTop(1).Render(line)
+}
+
+// fullKeyHelp renders every binding of km in columns, global keys last
+func fullKeyHelp(th *Theme, tr *Translator, width int, km help.KeyMap) string {
+	h := newHelp(th, width)
+	var groups [][]key.Binding
+	for _, group := range append(km.FullHelp(), globalKeys.ShortHelp()) {
+		groups = append(groups, translateKeys(tr, group))
+	}
+	return h.FullHelpView(groups)
+}
@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// keyMaps is every keymap a view can be in. A new view or stage has to be
+// added here to be checked.
+var keyMaps = map[string]any{
+	"home":          homeKeys,
+	"page":          pageKeys,
+	"blog":          blogKeys,
+	"blog post":     blogPostKeys,
+	"projects":      projectsKeys,
+	"project":       projectKeys,
+	"inbox":         inboxKeys,
+	"inbox thread":  threadKeys,
+	"inbox compose": composeKeys,
+	"contact":       contactKeys,
+	"contact check": confirmKeys,
+	"receipt":       receiptKeys,
+	"draft restore": restoreKeys,
+	"draft resume":  resumeKeys,
+	"arcade":        arcadeKeys,
+	"snake":         snakeKeys,
+	"snake setup":   snakeSetupKeys,
+	"replay":        replayKeys,
+	"replay load":   replayLoadKeys,
+	"arena lobby":   arenaLobbyKeys,
+	"arena":         arenaKeys,
+	"crt":           crtKeys,
+	"initials":      initialsKeys,
+	"high scores":   scoresKeys,
+	"secrets":       secretsKeys,
+	"palette":       paletteKeys,
+}
+
+// bindings collects the key.Binding fields of a keymap struct, nested
+// ones (like a viewport keymap) included
+func bindings(v reflect.Value, path string, out map[string]key.Binding) {
+	if b, ok := v.Interface().(key.Binding); ok {
+		out[path] = b
+		return
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < v.NumField(); i++ {
+		name := v.Type().Field(i).Name
+		if path != "" {
+			name = path + "." + name
+		}
+		bindings(v.Field(i), name, out)
+	}
+}
+
+func TestKeyMapsHaveNoConflicts(t *testing.T) {
+	for view, km := range keyMaps {
+		all := map[string]key.Binding{}
+		bindings(reflect.ValueOf(km), "", all)
+		bindings(reflect.ValueOf(globalKeys), "global", all)
+
+		owner := map[string]string{}
+		for name, b := range all {
+			if !b.Enabled() {
+				continue
+			}
+			for _, k := range b.Keys() {
+				if other, ok := owner[k]; ok {
+					t.Errorf("%s: %q is bound to both %s and %s", view, k, other, name)
+				}
+				owner[k] = name
+			}
+		}
+	}
+}
+
+func TestKeyHelpIsTranslated(t *testing.T) {
+	for view, km := range keyMaps {
+		all := map[string]key.Binding{}
+		bindings(reflect.ValueOf(km), "", all)
+		bindings(reflect.ValueOf(globalKeys), "global", all)
+
+		for name, b := range all {
+			if !b.Enabled() {
+				continue
+			}
+			desc := b.Help().Desc
+			if b.Help().Key == "" || desc == "" {
+				t.Errorf("%s: %s has no help", view, name)
+				continue
+			}
+			if _, ok := catalogEN[desc]; !ok {
+				t.Errorf("%s: %s help %q is missing from the English catalog", view, name, desc)
+			}
+			if _, ok := catalogPL[desc]; !ok {
+				t.Errorf("%s: %s help %q is missing from the Polish catalog", view, name, desc)
+			}
+		}
+	}
+}
+
+func TestHelpListsEveryBinding(t *testing.T) {
+	for view, km := range keyMaps {
+		all := map[string]key.Binding{}
+		bindings(reflect.ValueOf(km), "", all)
+
+		listed := map[string]bool{}
+		for _, group := range km.(interface{ FullHelp() [][]key.Binding }).FullHelp() {
+			for _, b := range group {
+				listed[b.Help().Key+" "+b.Help().Desc] = true
+			}
+		}
+		for name, b := range all {
+			if b.Enabled() && !listed[b.Help().Key+" "+b.Help().Desc] {
+				t.Errorf("%s: %s is missing from the full help", view, name)
+			}
+		}
+	}
+}
+
+// qExceptions are the views where q isn't a way back: home has nowhere to
+// go back to, and on a page or the receipt q shows the QR code
+var qExceptions = map[string]string{
+	"home":    "Quit",
+	"page":    "QR",
+	"receipt": "QR",
+}
+
+func TestQGoesBack(t *testing.T) {
+	for view, km := range keyMaps {
+		all := map[string]key.Binding{}
+		bindings(reflect.ValueOf(km), "", all)
+
+		for name, b := range all {
+			for _, k := range b.Keys() {
+				if k != "q" {
+					continue
+				}
+				want, ok := qExceptions[view]
+				switch {
+				case ok && name != want:
+					t.Errorf("%s: q is bound to %s, want %s", view, name, want)
+				case !ok && name != "Back" && name != "Close" && name != "Leave":
+					t.Errorf("%s: q is bound to %s, it should go back", view, name)
+				}
+			}
+		}
+	}
+}
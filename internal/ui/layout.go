@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// Below this the UI can't be drawn sensibly at all
+	minWidth  = 40
+	minHeight = 12
+
+	// Views switch to their compact layout under this many columns
+	compactWidth = 80
+)
+
+// compact reports whether a view of this width should use its narrow layout.
+// An unknown width (no WindowSizeMsg yet) counts as wide.
+func compact(width int) bool {
+	return width > 0 && width < compactWidth
+}
+
+// clamp keeps v within [low, high]
+func clamp(v, low, high int) int {
+	if v < low {
+		return low
+	}
+	if v > high {
+		return high
+	}
+	return v
+}
+
+// BoxFor is Box with slimmer padding in compact layouts
+func (t *Theme) BoxFor(width int) lipgloss.Style {
+	if compact(width) {
+		return t.Box.Padding(0, 1)
+	}
+	return t.Box
+}
+
+// RenderHelp renders help text, wrapped only when it wouldn't fit the window
+func (t *Theme) RenderHelp(width int, text string) string {
+	if width > 0 && lipgloss.Width(text)+8 > width {
+		return t.Help.Width(width - 8).Render(text)
+	}
+	return t.Help.Render(text)
+}
+
+// newPager creates the viewport pages taller than the window scroll in
+func newPager() viewport.Model {
+	return viewport.New(0, 0)
+}
+
+// bodyHeight is the room under the breadcrumb bar
+func (m Model) bodyHeight() int {
+	return m.height - breadcrumbHeight
+}
+
+// resizePager leaves the last line for the scroll hint
+func (m *Model) resizePager() {
+	m.pager.Width = m.width
+	m.pager.Height = m.bodyHeight() - 1
+}
+
+// lastFrame is the content the last View laid out. Like the zoneMap it's
+// shared by pointer: View records, scroll measures.
+type lastFrame struct {
+	mu   sync.Mutex
+	view string
+}
+
+func (f *lastFrame) record(view string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.view = view
+}
+
+func (f *lastFrame) content() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.view
+}
+
+// scroll handles pager keys and the mouse wheel. It reports whether the
+// message was used, so the view underneath doesn't see it too.
+func (m *Model) scroll(msg tea.Msg) bool {
+	var move func()
+	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		dir, ok := wheel(msg)
+		if !ok {
+			return false
+		}
+		move = func() { m.pager.ScrollDown(3) }
+		if dir < 0 {
+			move = func() { m.pager.ScrollUp(3) }
+		}
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "pgup":
+			move = func() { m.pager.PageUp() }
+		case "pgdown":
+			move = func() { m.pager.PageDown() }
+		default:
+			return false
+		}
+
+	default:
+		return false
+	}
+
+	// Measured on the last frame, rendering again just for its height
+	// would double the work of every scroll
+	content := m.frame.content()
+	if m.width == 0 || lipgloss.Height(content) <= m.bodyHeight() {
+		return false
+	}
+	m.pager.SetContent(content)
+	move()
+	return true
+}
+
+// layout puts the breadcrumb bar on top and centers content under it,
+// scrolls content when it's taller than the window and gives up politely
+// when the window is tiny
+func (m Model) layout(content string) string {
+	if m.width == 0 || m.height == 0 {
+		return content
+	}
+
+	if m.width < minWidth || m.height < minHeight {
+		notice := lipgloss.JoinVertical(lipgloss.Center,
+			m.theme.Error.UnsetPadding().Render(m.tr.T("layout.too_small")),
+			m.theme.Help.Render(m.tr.T("layout.too_small.size", m.width, m.height, minWidth, minHeight)),
+		)
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, notice)
+	}
+
+	bar := lipgloss.PlaceHorizontal(m.width, lipgloss.Left, " "+m.breadcrumbs())
+	if lipgloss.Height(content) <= m.bodyHeight() {
+		return bar + "\n" + lipgloss.Place(m.width, m.bodyHeight(), lipgloss.Center, lipgloss.Center, content)
+	}
+
+	// Center horizontally, then let the pager cut out the visible part
+	content = lipgloss.PlaceHorizontal(m.width, lipgloss.Center, content)
+	pager := m.pager
+	pager.SetContent(content)
+
+	hint := m.tr.T("layout.scroll", int(pager.ScrollPercent()*100))
+	hint = m.theme.Help.UnsetMarginTop().Render(strings.TrimSpace(hint))
+	return bar + "\n" + pager.View() + "\n" + lipgloss.PlaceHorizontal(m.width, lipgloss.Center, hint)
+}
@@ -0,0 +1,333 @@
+package ui
+
+// catalogEN is the English message catalog and the fallback for every other locale
+var catalogEN = map[string]string{
+	// Keys
+	"keys.title":        "Keyboard shortcuts",
+	"keys.close":        "Any key to close",
+	"keys.help":         "all keys",
+	"keys.quit":         "quit",
+	"keys.up":           "up",
+	"keys.down":         "down",
+	"keys.left":         "left",
+	"keys.right":        "right",
+	"keys.page_up":      "page up",
+	"keys.page_down":    "page down",
+	"keys.half_up":      "half page up",
+	"keys.half_down":    "half page down",
+	"keys.top":          "top",
+	"keys.bottom":       "bottom",
+	"keys.select":       "select",
+	"keys.open":         "open",
+	"keys.back":         "back",
+	"keys.back_list":    "back to the list",
+	"keys.language":     "język polski",
+	"keys.theme":        "next theme",
+	"keys.next_link":    "next link",
+	"keys.prev_link":    "previous link",
+	"keys.copy":         "copy link",
+	"keys.qr":           "QR code",
+	"keys.open_link":    "open link",
+	"keys.prev_page":    "previous page",
+	"keys.next_page":    "next page",
+	"keys.refresh":      "refresh",
+	"keys.read":         "read",
+	"keys.details":      "details",
+	"keys.reply":        "reply",
+	"keys.send":         "send",
+	"keys.cancel":       "cancel",
+	"keys.next_field":   "next field",
+	"keys.prev_field":   "previous field",
+	"keys.press":        "submit / go back",
+	"keys.resume":       "resume a draft",
+	"keys.prev":         "previous",
+	"keys.next":         "next",
+	"keys.edit":         "edit",
+	"keys.confirm":      "confirm",
+	"keys.qr_card":      "QR contact card",
+	"keys.restore":      "restore",
+	"keys.discard":      "discard",
+	"keys.lookup":       "look up",
+	"keys.launch":       "launch",
+	"keys.respawn":      "respawn",
+	"keys.crt_off":      "turn it off (or stare at this glitch forever)",
+	"keys.save":         "save",
+	"keys.letter_prev":  "previous letter",
+	"keys.letter_next":  "next letter",
+	"keys.pause":        "pause",
+	"keys.next_level":   "next level",
+	"keys.start":        "start",
+	"keys.prev_mode":    "previous mode",
+	"keys.next_mode":    "next mode",
+	"keys.watch_replay": "watch replay",
+	"keys.share_replay": "share replay",
+	"keys.slower":       "slower",
+	"keys.faster":       "faster",
+	"keys.restart":      "from the top",
+	"keys.load":         "load",
+	"keys.join":         "join",
+	"keys.skip":         "skip",
+	"keys.palette":      "go to anything",
+	"keys.go":           "go",
+	"keys.backward":     "previous screen",
+	"keys.forward":      "next screen",
+
+	// Navigation
+	"nav.home": "Home",
+
+	// Toasts
+	"toast.copied":           "Copied %s to your clipboard",
+	"toast.copy.unsupported": "This terminal can't copy, select it by hand: %s",
+
+	// Layout
+	"layout.too_small":      "Terminal too small",
+	"layout.too_small.size": "%dx%d now, needs at least %dx%d",
+	"layout.scroll":         "PgUp/PgDn or mouse wheel to scroll • %d%%",
+
+	// Home
+	"menu.contact.title":  "Contact",
+	"menu.contact.desc":   "Send me a message",
+	"menu.blog.title":     "Blog",
+	"menu.blog.desc":      "Latest posts from pcstyle.dev",
+	"menu.projects.title": "Projects",
+	"menu.projects.desc":  "Things I've built, with live status",
+	"menu.exit.title":     "Exit",
+	"menu.exit.desc":      "Disconnect from SSH",
+	"menu.arcade.title":   "Arcade",
+	"menu.arcade.desc":    "play snake + weird stuff",
+	"menu.secrets.title":  "???",
+	"menu.secrets.desc":   "weird logbook, don't judge",
+	"menu.inbox.title":    "Inbox",
+	"menu.inbox.desc":     "Replies to your messages",
+	"home.subtitle":       "SSH Terminal Interface",
+	"home.welcome":        "Welcome to pcstyle.dev SSH interface",
+	"home.replies.one":    "1 new reply in your Inbox",
+	"home.replies.many":   "%d new replies in your Inbox",
+	"home.theme":          "theme: %s • t to switch",
+	"home.unlocked":       "ok... arcade booted, good luck",
+	"home.bonus":          "bonus: type 'snake' or 'games' some time. %s",
+
+	// Contact
+	"contact.title":                "Contact Form",
+	"contact.label.message":        "Message *",
+	"contact.label.name":           "Name",
+	"contact.label.email":          "Email",
+	"contact.label.discord":        "Discord",
+	"contact.label.phone":          "Phone",
+	"contact.label.facebook":       "Facebook",
+	"contact.placeholder.message":  "Enter your message here...",
+	"contact.placeholder.name":     "Your name (optional)",
+	"contact.placeholder.email":    "your.email@example.com (optional)",
+	"contact.placeholder.discord":  "@yourusername (optional)",
+	"contact.placeholder.phone":    "+1234567890 (optional)",
+	"contact.placeholder.facebook": "facebook.com/yourprofile (optional)",
+	"contact.submit":               "Submit",
+	"contact.back":                 "Back",
+	"contact.submitting":           "Submitting...",
+	"contact.sending":              "Sending...",
+	"contact.fix_fields":           "Please fix the highlighted fields",
+	"contact.review.title":         "Review your message",
+	"contact.review.where":         "This JSON is posted to the contact API above and forwarded to a private Discord channel.",
+	"contact.review.what":          "Only the fields shown above are sent. Your SSH key and IP address are not included.",
+	"contact.review.edit":          "Edit",
+	"contact.review.send":          "Send",
+	"contact.review.cancel":        "Cancel",
+	"contact.receipt.title":        "Message sent",
+	"contact.receipt.reference":    "Reference:",
+	"contact.receipt.quote":        "Quote this reference if you follow up about this message.",
+	"contact.receipt.inbox":        "Replies will show up in your inbox when you reconnect with this SSH key.",
+
+	// Validation
+	"validate.message.required": "Message is required",
+	"validate.message.long":     "Message must be at most 2000 characters",
+	"validate.name.long":        "Name must be at most 100 characters",
+	"validate.email.long":       "Email must be at most 100 characters",
+	"validate.email.invalid":    "Invalid email address",
+	"validate.phone.long":       "Phone must be at most 50 characters",
+	"validate.phone.invalid":    "Use international format, e.g. +48 123 456 789",
+	"validate.discord.long":     "Discord must be at most 100 characters",
+	"validate.discord.invalid":  "Discord usernames are 2-32 chars: a-z, 0-9, _ and .",
+	"validate.discord.periods":  "Discord usernames can't contain consecutive periods",
+	"validate.facebook.long":    "Facebook URL must be at most 200 characters",
+	"validate.facebook.url":     "Invalid URL",
+	"validate.facebook.host":    "Must be a facebook.com profile link",
+
+	// Drafts
+	"draft.not_found":     "No draft found for that code (drafts expire after a while)",
+	"draft.locked":        "Too many wrong codes, reconnect to try again",
+	"draft.hint.key":      "Drafts autosave to your SSH key",
+	"draft.hint.code":     "Drafts autosave • resume code: %s • ctrl+r to enter an old code",
+	"draft.restore.title": "Restore your unsent draft?",
+	"draft.restore.saved": "Saved %s",
+	"draft.resume.title":  "Resume a draft",
+	"draft.resume.label":  "Resume code:",
+
+	// Inbox
+	"inbox.empty":       "No conversations yet. Send a message from Contact to start one.",
+	"inbox.new":         "%d new",
+	"inbox.you":         "you",
+	"inbox.sent":        "follow-up sent",
+	"inbox.placeholder": "Write a follow-up...",
+
+	// Arcade
+	"arcade.hub":                   "mini arcade hub",
+	"arcade.snake.title":           "SNAKE.exe",
+	"arcade.snake.desc":            "classic borderline laggy snake",
+	"arcade.crt.title":             "CRT DREAM",
+	"arcade.crt.desc":              "just a vibey screensaver, no controls",
+	"arcade.status.booting":        "booting tiny arcade... hold on",
+	"arcade.status.ready":          "ok arcade ready, let's go",
+	"arcade.status.dead":           "rip snake, press r to respawn",
+	"arcade.status.crt_on":         "enjoy the glitch, i guess",
+	"arcade.status.crt_off":        "ok screensaver off, heading back",
+	"arcade.status.snake_loaded":   "snake loaded, please don't crash into yourself",
+	"arcade.status.snake_left":     "snake paused, probably hungry",
+	"arcade.status.respawned":      "respawned, good luck",
+	"snake.title":                  "SNAKE.exe // food for nostalgia",
+	"snake.score":                  "score: %d",
+	"snake.dead":                   "you died. r = retry, esc = leave",
+	"snake.not_booted":             "snake not booted??? how did that even...",
+	"arcade.status.snake_setup":    "pick your poison",
+	"arcade.status.snake_waiting":  "snake's still here, p to keep going",
+	"arcade.status.paused":         "paused, snake is waiting patiently",
+	"arcade.status.resumed":        "and we're back",
+	"arcade.status.level_complete": "level cleared, nice",
+	"arcade.status.next_level":     "next level, more walls, good luck",
+	"snake.hud":                    "score: %d • level %d • best %d",
+	"snake.bonus":                  "bonus %d",
+	"snake.paused":                 "paused. p = resume, esc = leave",
+	"snake.level_complete":         "LEVEL %d COMPLETE. enter = next level",
+	"snake.difficulty.easy":        "easy",
+	"snake.difficulty.normal":      "normal",
+	"snake.difficulty.hard":        "hard",
+	"snake.walls.solid":            "solid walls",
+	"snake.walls.wrap":             "wrap-around",
+	"snake.setup.title":            "SNAKE.exe // new game",
+	"snake.setup.difficulty":       "difficulty",
+	"snake.setup.walls":            "walls",
+	"snake.setup.goal":             "%d apples clear a level, bonus apples don't stick around",
+	"snake.setup.best":             "best in this mode: %d",
+	"snake.setup.start":            "START",
+	"scores.mode":                  "mode: %s",
+	"arcade.replay.title":          "REPLAY",
+	"arcade.replay.desc":           "watch a snake game someone shared",
+	"arcade.status.replay_rolling": "rolling the tape",
+	"arcade.status.replay_over":    "that's the whole tape",
+	"arcade.status.replay_left":    "tape ejected",
+	"arcade.status.replay_copied":  "replay code copied, send it to a friend",
+	"arcade.status.replay_paste":   "got a code? paste it here",
+	"arcade.status.replay_bad":     "that's not a replay code (or it got cut off)",
+	"replay.title":                 "REPLAY // instant replay",
+	"replay.hud":                   "score: %d • level %d • tick %d/%d • %s",
+	"replay.paused":                "paused. p = play",
+	"replay.over":                  "THE END. r = again, esc = leave",
+	"replay.load_title":            "REPLAY // load a code",
+	"replay.prompt":                "paste a replay code someone shared:",
+	"replay.placeholder":           "replay code",
+	"snake.share":                  "your replay code:",
+	"crt.title":                    "CRT DREAM // yes, completely useless",
+	"arcade.scores.title":          "HIGH SCORES",
+	"arcade.scores.desc":           "top 10s: all-time, weekly, daily",
+	"arcade.status.high_score":     "new high score!!! sign it",
+	"arcade.status.score_saved":    "you're on the board, legend",
+	"arcade.status.score_rejected": "the board didn't believe that score, sus",
+	"arcade.status.score_failed":   "couldn't save the score, the board is down :(",
+	"arcade.status.scores_left":    "ok enough bragging",
+	"scores.title":                 "HIGH SCORES // hall of fame",
+	"scores.all_time":              "all-time",
+	"scores.week":                  "this week",
+	"scores.today":                 "today",
+	"scores.empty":                 "no scores yet, go set one",
+	"scores.you":                   "< you",
+	"scores.new_high":              "NEW HIGH SCORE",
+	"scores.enter_initials":        "enter your initials:",
+	"arcade.arena.title":           "SNAKE VS",
+	"arcade.arena.desc":            "multiplayer snake with whoever's around",
+	"arcade.status.arena_lobby":    "pick a room or just quick match",
+	"arcade.status.arena_joined":   "you're in, steer with the arrows",
+	"arcade.status.arena_watching": "room's full, spectator mode it is",
+	"arcade.status.arena_left":     "left the room, no hard feelings",
+	"arcade.status.arena_closed":   "the room closed on us",
+	"arcade.status.arena_gone":     "that room is gone already",
+	"arena.lobby_title":            "SNAKE VS // lobby",
+	"arena.quick":                  "QUICK MATCH",
+	"arena.quick_desc":             "jump into the next game",
+	"arena.new":                    "NEW ROOM",
+	"arena.new_desc":               "open one and wait for company",
+	"arena.no_rooms":               "no rooms open, be the first",
+	"arena.room":                   "room #%d  %d/%d players • %s",
+	"arena.full":                   "full, join to watch",
+	"arena.spectators":             "%d watching",
+	"arena.phase.waiting":          "waiting",
+	"arena.phase.countdown":        "starting",
+	"arena.phase.playing":          "playing",
+	"arena.phase.over":             "round over",
+	"arena.title":                  "SNAKE VS // room #%d",
+	"arena.joining":                "joining...",
+	"arena.player":                 "P%d",
+	"arena.legend":                 "%s  %d pts • %d wins",
+	"arena.you":                    "< you",
+	"arena.out":                    "out",
+	"arena.waiting":                "waiting for someone to play with...",
+	"arena.countdown":              "round %d starts in %ds, get ready",
+	"arena.go":                     "round %d, go go go",
+	"arena.winner":                 "%s wins round %d! next one in %ds",
+	"arena.draw":                   "round %d is a draw, next one in %ds",
+	"arena.spectating":             "watching • %d in the audience",
+
+	// Secrets
+	"secrets.status.opening": "opening the logbook... hold on",
+	"secrets.status.prev":    "went back an entry • chill",
+	"secrets.status.next":    "next log... don't judge",
+	"secrets.status.skip":    "skipping just because",
+	"secrets.empty":          "the logbook is empty. suspicious.",
+
+	// Blog
+	"blog.loading":   "Fetching the feed...",
+	"blog.error":     "Couldn't load the blog: %s",
+	"blog.empty":     "No posts yet.",
+	"blog.stale":     "offline copy from %s, the site couldn't be reached",
+	"blog.page":      "page %d/%d",
+	"blog.post.page": "page %d/%d",
+
+	// Projects
+	"projects.empty":    "No projects to show.",
+	"projects.up":       "up %dms",
+	"projects.down":     "down",
+	"projects.checking": "checking",
+	"projects.stack":    "Stack:",
+	"projects.open":     "Open:",
+
+	// Pages
+	"page.missing": "This page doesn't exist (anymore?)",
+
+	// QR codes
+	"qr.scan":      "Scan it with your phone's camera",
+	"qr.too_small": "Make the window bigger to see the QR code, or use this:",
+	"qr.help":      "Any key to close",
+	"qr.card.note": "Re: message %s",
+
+	// Command palette
+	"palette.title":       "Go to",
+	"palette.placeholder": "Type to search…",
+	"palette.empty":       "Nothing matches.",
+	"palette.theme":       "Theme: %s",
+	"palette.copy_email":  "Copy email (%s)",
+	"palette.kind.view":   "view",
+	"palette.kind.page":   "page",
+	"palette.kind.secret": "secret",
+	"palette.kind.game":   "game",
+	"palette.kind.theme":  "theme",
+	"palette.kind.action": "action",
+
+	// Goodbye
+	"goodbye.banner": `
+  _____ _                 _                       _
+ |_   _| |__   __ _ _ __ | | __  _   _  ___  _  _| |
+   | | | '_ \ / _' | '_ \| |/ / | | | |/ _ \| || | |
+   | | | | | | (_| | | | |   <  | |_| | (_) | || |_|
+   |_| |_| |_|\__,_|_| |_|_|\_\  \__, |\___/ \_,_(_)
+                                 |___/
+`,
+	"goodbye.thanks": "Thanks for visiting pcstyle.dev via SSH!",
+}
@@ -0,0 +1,332 @@
+package ui
+
+// catalogPL to polski katalog; brakujące klucze lecą z angielskiego
+var catalogPL = map[string]string{
+	// Klawisze
+	"keys.title":        "Skróty klawiszowe",
+	"keys.close":        "Dowolny klawisz zamyka",
+	"keys.help":         "wszystkie klawisze",
+	"keys.quit":         "wyjdź",
+	"keys.up":           "w górę",
+	"keys.down":         "w dół",
+	"keys.left":         "w lewo",
+	"keys.right":        "w prawo",
+	"keys.page_up":      "strona w górę",
+	"keys.page_down":    "strona w dół",
+	"keys.half_up":      "pół strony w górę",
+	"keys.half_down":    "pół strony w dół",
+	"keys.top":          "początek",
+	"keys.bottom":       "koniec",
+	"keys.select":       "wybierz",
+	"keys.open":         "otwórz",
+	"keys.back":         "wróć",
+	"keys.back_list":    "wróć do listy",
+	"keys.language":     "English",
+	"keys.theme":        "następny motyw",
+	"keys.next_link":    "następny link",
+	"keys.prev_link":    "poprzedni link",
+	"keys.copy":         "kopiuj link",
+	"keys.qr":           "kod QR",
+	"keys.open_link":    "otwórz link",
+	"keys.prev_page":    "poprzednia strona",
+	"keys.next_page":    "następna strona",
+	"keys.refresh":      "odśwież",
+	"keys.read":         "czytaj",
+	"keys.details":      "szczegóły",
+	"keys.reply":        "odpowiedz",
+	"keys.send":         "wyślij",
+	"keys.cancel":       "anuluj",
+	"keys.next_field":   "następne pole",
+	"keys.prev_field":   "poprzednie pole",
+	"keys.press":        "wyślij / wróć",
+	"keys.resume":       "wznów szkic",
+	"keys.prev":         "poprzedni",
+	"keys.next":         "następny",
+	"keys.edit":         "edytuj",
+	"keys.confirm":      "potwierdź",
+	"keys.qr_card":      "wizytówka QR",
+	"keys.restore":      "przywróć",
+	"keys.discard":      "odrzuć",
+	"keys.lookup":       "szukaj",
+	"keys.launch":       "odpal",
+	"keys.respawn":      "respawn",
+	"keys.crt_off":      "wyłącz (albo gap się w ten glitch wiecznie)",
+	"keys.save":         "zapisz",
+	"keys.letter_prev":  "poprzednia litera",
+	"keys.letter_next":  "następna litera",
+	"keys.pause":        "pauza",
+	"keys.next_level":   "następny level",
+	"keys.start":        "start",
+	"keys.prev_mode":    "poprzedni tryb",
+	"keys.next_mode":    "następny tryb",
+	"keys.watch_replay": "obejrzyj replay",
+	"keys.share_replay": "udostępnij replay",
+	"keys.slower":       "wolniej",
+	"keys.faster":       "szybciej",
+	"keys.restart":      "od początku",
+	"keys.load":         "wczytaj",
+	"keys.join":         "dołącz",
+	"keys.skip":         "pomiń",
+	"keys.palette":      "idź gdziekolwiek",
+	"keys.go":           "idź",
+	"keys.backward":     "poprzedni ekran",
+	"keys.forward":      "następny ekran",
+
+	// Nawigacja
+	"nav.home": "Start",
+
+	// Powiadomienia
+	"toast.copied":           "Skopiowano %s do schowka",
+	"toast.copy.unsupported": "Ten terminal nie umie kopiować, zaznacz ręcznie: %s",
+
+	// Układ
+	"layout.too_small":      "Za mały terminal",
+	"layout.too_small.size": "teraz %dx%d, potrzeba co najmniej %dx%d",
+	"layout.scroll":         "PgUp/PgDn albo kółko myszy przewija • %d%%",
+
+	// Home
+	"menu.contact.title":  "Kontakt",
+	"menu.contact.desc":   "Napisz do mnie",
+	"menu.blog.title":     "Blog",
+	"menu.blog.desc":      "Najnowsze wpisy z pcstyle.dev",
+	"menu.projects.title": "Projekty",
+	"menu.projects.desc":  "Rzeczy, które zbudowałem, z live statusem",
+	"menu.exit.title":     "Wyjście",
+	"menu.exit.desc":      "Rozłącz się z SSH",
+	"menu.arcade.title":   "Arcade",
+	"menu.arcade.desc":    "snake + dziwne rzeczy",
+	"menu.secrets.title":  "???",
+	"menu.secrets.desc":   "dziwny dziennik, nie oceniaj",
+	"menu.inbox.title":    "Skrzynka",
+	"menu.inbox.desc":     "Odpowiedzi na twoje wiadomości",
+	"home.subtitle":       "Terminal przez SSH",
+	"home.welcome":        "Witaj w terminalu pcstyle.dev przez SSH",
+	"home.replies.one":    "1 nowa odpowiedź w skrzynce",
+	"home.replies.many":   "Nowe odpowiedzi w skrzynce: %d",
+	"home.theme":          "motyw: %s • t zmienia",
+	"home.unlocked":       "ok... arcade odpalone, powodzenia",
+	"home.bonus":          "bonus: wpisz kiedyś 'snake' albo 'games'. %s",
+
+	// Kontakt
+	"contact.title":                "Formularz kontaktowy",
+	"contact.label.message":        "Wiadomość *",
+	"contact.label.name":           "Imię",
+	"contact.label.email":          "Email",
+	"contact.label.discord":        "Discord",
+	"contact.label.phone":          "Telefon",
+	"contact.label.facebook":       "Facebook",
+	"contact.placeholder.message":  "Tu wpisz wiadomość...",
+	"contact.placeholder.name":     "Twoje imię (opcjonalnie)",
+	"contact.placeholder.email":    "twoj.email@example.com (opcjonalnie)",
+	"contact.placeholder.discord":  "@twojanazwa (opcjonalnie)",
+	"contact.placeholder.phone":    "+48123456789 (opcjonalnie)",
+	"contact.placeholder.facebook": "facebook.com/twojprofil (opcjonalnie)",
+	"contact.submit":               "Wyślij",
+	"contact.back":                 "Wróć",
+	"contact.submitting":           "Wysyłam...",
+	"contact.sending":              "Wysyłam...",
+	"contact.fix_fields":           "Popraw zaznaczone pola",
+	"contact.review.title":         "Sprawdź wiadomość",
+	"contact.review.where":         "Ten JSON idzie do powyższego API kontaktowego, a stamtąd na prywatny kanał na Discordzie.",
+	"contact.review.what":          "Wysyłamy tylko pola widoczne powyżej. Twój klucz SSH i adres IP nie są dołączane.",
+	"contact.review.edit":          "Edytuj",
+	"contact.review.send":          "Wyślij",
+	"contact.review.cancel":        "Anuluj",
+	"contact.receipt.title":        "Wiadomość wysłana",
+	"contact.receipt.reference":    "Numer referencyjny:",
+	"contact.receipt.quote":        "Podaj ten numer, jeśli będziesz dopytywać o tę wiadomość.",
+	"contact.receipt.inbox":        "Odpowiedzi pojawią się w skrzynce, gdy połączysz się ponownie tym kluczem SSH.",
+
+	// Walidacja
+	"validate.message.required": "Wiadomość jest wymagana",
+	"validate.message.long":     "Wiadomość może mieć najwyżej 2000 znaków",
+	"validate.name.long":        "Imię może mieć najwyżej 100 znaków",
+	"validate.email.long":       "Email może mieć najwyżej 100 znaków",
+	"validate.email.invalid":    "Nieprawidłowy adres email",
+	"validate.phone.long":       "Telefon może mieć najwyżej 50 znaków",
+	"validate.phone.invalid":    "Użyj formatu międzynarodowego, np. +48 123 456 789",
+	"validate.discord.long":     "Discord może mieć najwyżej 100 znaków",
+	"validate.discord.invalid":  "Nazwa na Discordzie to 2-32 znaki: a-z, 0-9, _ i .",
+	"validate.discord.periods":  "Nazwa na Discordzie nie może mieć dwóch kropek pod rząd",
+	"validate.facebook.long":    "Link do Facebooka może mieć najwyżej 200 znaków",
+	"validate.facebook.url":     "Nieprawidłowy URL",
+	"validate.facebook.host":    "To musi być link do profilu na facebook.com",
+
+	// Szkice
+	"draft.not_found":     "Brak szkicu dla tego kodu (szkice po jakimś czasie wygasają)",
+	"draft.locked":        "Za dużo złych kodów, połącz się ponownie, żeby spróbować jeszcze raz",
+	"draft.hint.key":      "Szkic zapisuje się automatycznie pod twoim kluczem SSH",
+	"draft.hint.code":     "Szkic zapisuje się sam • kod wznowienia: %s • ctrl+r, żeby wpisać stary kod",
+	"draft.restore.title": "Przywrócić niewysłany szkic?",
+	"draft.restore.saved": "Zapisano %s",
+	"draft.resume.title":  "Wznów szkic",
+	"draft.resume.label":  "Kod wznowienia:",
+
+	// Skrzynka
+	"inbox.empty":       "Na razie pusto. Wyślij wiadomość z Kontaktu, żeby zacząć rozmowę.",
+	"inbox.new":         "nowe: %d",
+	"inbox.you":         "ty",
+	"inbox.sent":        "odpowiedź wysłana",
+	"inbox.placeholder": "Napisz odpowiedź...",
+
+	// Arcade
+	"arcade.hub":                   "mini salon gier",
+	"arcade.snake.title":           "SNAKE.exe",
+	"arcade.snake.desc":            "klasyczny, lekko lagujący snake",
+	"arcade.crt.title":             "CRT DREAM",
+	"arcade.crt.desc":              "taki wygaszacz dla klimatu, bez sterowania",
+	"arcade.status.booting":        "odpalam małe arcade... chwila",
+	"arcade.status.ready":          "ok arcade gotowe, lecimy",
+	"arcade.status.dead":           "rip snake, wciśnij r żeby zrespawnić",
+	"arcade.status.crt_on":         "miłego glitcha, chyba",
+	"arcade.status.crt_off":        "ok wygaszacz off, wracamy",
+	"arcade.status.snake_loaded":   "snake załadowany, nie wjedź w siebie pls",
+	"arcade.status.snake_left":     "snake zapauzowany, pewnie głodny",
+	"arcade.status.respawned":      "zrespawnowany, powodzenia elo",
+	"snake.title":                  "SNAKE.exe // karma dla nostalgii",
+	"snake.score":                  "wynik: %d",
+	"snake.dead":                   "padłeś. r = jeszcze raz, esc = wyjdź",
+	"snake.not_booted":             "snake nie wystartował??? jak to w ogóle...",
+	"arcade.status.snake_setup":    "wybierz swoją truciznę",
+	"arcade.status.snake_waiting":  "snake dalej tu jest, p żeby grać dalej",
+	"arcade.status.paused":         "pauza, snake cierpliwie czeka",
+	"arcade.status.resumed":        "i wracamy",
+	"arcade.status.level_complete": "level zaliczony, nieźle",
+	"arcade.status.next_level":     "następny level, więcej ścian, powodzenia",
+	"snake.hud":                    "wynik: %d • level %d • rekord %d",
+	"snake.bonus":                  "bonus %d",
+	"snake.paused":                 "pauza. p = dalej, esc = wyjdź",
+	"snake.level_complete":         "LEVEL %d ZALICZONY. enter = następny",
+	"snake.difficulty.easy":        "łatwy",
+	"snake.difficulty.normal":      "normalny",
+	"snake.difficulty.hard":        "trudny",
+	"snake.walls.solid":            "twarde ściany",
+	"snake.walls.wrap":             "bez ścian",
+	"snake.setup.title":            "SNAKE.exe // nowa gra",
+	"snake.setup.difficulty":       "poziom",
+	"snake.setup.walls":            "ściany",
+	"snake.setup.goal":             "%d jabłek zalicza level, bonusowe długo nie leżą",
+	"snake.setup.best":             "rekord w tym trybie: %d",
+	"snake.setup.start":            "START",
+	"scores.mode":                  "tryb: %s",
+	"arcade.replay.title":          "REPLAY",
+	"arcade.replay.desc":           "obejrzyj czyjąś grę w snake'a",
+	"arcade.status.replay_rolling": "puszczam taśmę",
+	"arcade.status.replay_over":    "to cała taśma",
+	"arcade.status.replay_left":    "taśma wyjęta",
+	"arcade.status.replay_copied":  "kod replayu skopiowany, wyślij go znajomym",
+	"arcade.status.replay_paste":   "masz kod? wklej go tutaj",
+	"arcade.status.replay_bad":     "to nie jest kod replayu (albo się uciął)",
+	"replay.title":                 "REPLAY // powtórka",
+	"replay.hud":                   "wynik: %d • level %d • tick %d/%d • %s",
+	"replay.paused":                "pauza. p = graj",
+	"replay.over":                  "KONIEC. r = jeszcze raz, esc = wyjdź",
+	"replay.load_title":            "REPLAY // wczytaj kod",
+	"replay.prompt":                "wklej kod replayu, który ktoś ci wysłał:",
+	"replay.placeholder":           "kod replayu",
+	"snake.share":                  "twój kod replayu:",
+	"crt.title":                    "CRT DREAM // tak, kompletnie bezużyteczne",
+	"arcade.scores.title":          "HIGH SCORES",
+	"arcade.scores.desc":           "top 10: od zawsze, tydzień, dziś",
+	"arcade.status.high_score":     "nowy rekord!!! podpisz się",
+	"arcade.status.score_saved":    "jesteś na tablicy, legenda",
+	"arcade.status.score_rejected": "tablica nie uwierzyła w ten wynik, sus",
+	"arcade.status.score_failed":   "nie udało się zapisać wyniku, tablica leży :(",
+	"arcade.status.scores_left":    "ok, dość przechwałek",
+	"scores.title":                 "HIGH SCORES // galeria sław",
+	"scores.all_time":              "od zawsze",
+	"scores.week":                  "ten tydzień",
+	"scores.today":                 "dziś",
+	"scores.empty":                 "jeszcze nikt nie grał, bądź pierwszy",
+	"scores.you":                   "< ty",
+	"scores.new_high":              "NOWY REKORD",
+	"scores.enter_initials":        "wpisz inicjały:",
+	"arcade.arena.title":           "SNAKE VS",
+	"arcade.arena.desc":            "snake na kilka osób, z kimkolwiek kto jest",
+	"arcade.status.arena_lobby":    "wybierz pokój albo leć w quick match",
+	"arcade.status.arena_joined":   "jesteś w grze, sterujesz strzałkami",
+	"arcade.status.arena_watching": "pokój pełny, to sobie popatrzysz",
+	"arcade.status.arena_left":     "wyszedłeś z pokoju, bez urazy",
+	"arcade.status.arena_closed":   "pokój się zamknął",
+	"arcade.status.arena_gone":     "tego pokoju już nie ma",
+	"arena.lobby_title":            "SNAKE VS // lobby",
+	"arena.quick":                  "QUICK MATCH",
+	"arena.quick_desc":             "wskocz do najbliższej gry",
+	"arena.new":                    "NOWY POKÓJ",
+	"arena.new_desc":               "otwórz swój i czekaj na ekipę",
+	"arena.no_rooms":               "nie ma żadnych pokoi, bądź pierwszy",
+	"arena.room":                   "pokój #%d  %d/%d graczy • %s",
+	"arena.full":                   "pełny, wejdziesz jako widz",
+	"arena.spectators":             "%d ogląda",
+	"arena.phase.waiting":          "czeka",
+	"arena.phase.countdown":        "zaraz start",
+	"arena.phase.playing":          "gra trwa",
+	"arena.phase.over":             "koniec rundy",
+	"arena.title":                  "SNAKE VS // pokój #%d",
+	"arena.joining":                "wchodzę...",
+	"arena.player":                 "G%d",
+	"arena.legend":                 "%s  %d pkt • %d wygr.",
+	"arena.you":                    "< ty",
+	"arena.out":                    "odpadł",
+	"arena.waiting":                "czekamy aż ktoś dołączy...",
+	"arena.countdown":              "runda %d za %ds, szykuj się",
+	"arena.go":                     "runda %d, jazda",
+	"arena.winner":                 "%s wygrywa rundę %d! następna za %ds",
+	"arena.draw":                   "runda %d na remis, następna za %ds",
+	"arena.spectating":             "oglądasz • widzów: %d",
+
+	// Sekrety
+	"secrets.status.opening": "otwieram dziennik... chwila",
+	"secrets.status.prev":    "cofnąłem wpis • spokojnie",
+	"secrets.status.next":    "następny wpis... nie oceniaj",
+	"secrets.status.skip":    "pomijam, bo tak",
+	"secrets.empty":          "dziennik jest pusty. podejrzane.",
+
+	// Blog
+	"blog.loading":   "Pobieram feed...",
+	"blog.error":     "Nie udało się wczytać bloga: %s",
+	"blog.empty":     "Na razie brak wpisów.",
+	"blog.stale":     "kopia offline z %s, strona nie odpowiada",
+	"blog.page":      "strona %d/%d",
+	"blog.post.page": "strona %d/%d",
+
+	// Projekty
+	"projects.empty":    "Brak projektów do pokazania.",
+	"projects.up":       "działa %dms",
+	"projects.down":     "leży",
+	"projects.checking": "sprawdzam",
+	"projects.stack":    "Stack:",
+	"projects.open":     "Otwórz:",
+
+	// Strony
+	"page.missing": "Tej strony nie ma (już?)",
+
+	// Kody QR
+	"qr.scan":      "Zeskanuj aparatem w telefonie",
+	"qr.too_small": "Powiększ okno, żeby zobaczyć kod QR, albo użyj tego:",
+	"qr.help":      "Dowolny klawisz zamyka",
+	"qr.card.note": "Dot. wiadomości %s",
+
+	// Paleta poleceń
+	"palette.title":       "Idź do",
+	"palette.placeholder": "Wpisz, żeby szukać…",
+	"palette.empty":       "Nic nie pasuje.",
+	"palette.theme":       "Motyw: %s",
+	"palette.copy_email":  "Kopiuj e-mail (%s)",
+	"palette.kind.view":   "widok",
+	"palette.kind.page":   "strona",
+	"palette.kind.secret": "sekret",
+	"palette.kind.game":   "gra",
+	"palette.kind.theme":  "motyw",
+	"palette.kind.action": "akcja",
+
+	// Pożegnanie
+	"goodbye.banner": `
+   ____         _         _     _  _
+  |  _ \  ____ (_)  ___  | | __(_)| |
+  | | | ||_  / | | / _ \ | |/ /| || |
+  | |_| | / /  | ||  __/ |   < | ||_|
+  |____/ /___| |_| \___| |_|\_\|_|(_)
+`,
+	"goodbye.thanks": "Dzięki za wizytę na pcstyle.dev przez SSH!",
+}
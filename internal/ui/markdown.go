@@ -0,0 +1,288 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	orderedItem = regexp.MustCompile(`^(\d+)[.)]\s+`)
+	linkPattern = regexp.MustCompile(`^\[([^\]]+)\]\(([^)\s]+)\)`)
+)
+
+// renderMarkdown draws the subset of Markdown content pages use: headings,
+// paragraphs, lists, block quotes, rules, fenced code blocks and inline
+// bold, italics, code and links. width is the space available for text.
+func renderMarkdown(src string, th *Theme, width int) string {
+	md := &markdown{th: th, selected: -1}
+	return md.render(src, width)
+}
+
+// renderMarkdownLinks is renderMarkdown that also returns every link target
+// in order, with link number selected highlighted (-1 for none)
+func renderMarkdownLinks(src string, th *Theme, width, selected int) (string, []string) {
+	md := &markdown{th: th, selected: selected}
+	out := md.render(src, width)
+	return out, md.links
+}
+
+// markdown carries what inline rendering needs to number links
+type markdown struct {
+	th       *Theme
+	selected int
+	links    []string
+}
+
+func (md *markdown) render(src string, width int) string {
+	th := md.th
+	if width <= 0 {
+		width = 76
+	}
+
+	var (
+		out       []mdBlock
+		paragraph []string
+		code      []string
+		inCode    bool
+	)
+
+	add := func(kind mdKind, text string) {
+		out = append(out, mdBlock{kind: kind, text: text})
+	}
+	flush := func() {
+		if len(paragraph) == 0 {
+			return
+		}
+		add(mdParagraph, md.wrapInline(strings.Join(paragraph, ""), width))
+		paragraph = nil
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		// Fenced code blocks are shown as typed
+		if strings.HasPrefix(trimmed, "```") {
+			if inCode {
+				add(mdCode, th.CodeBlock.Render(strings.Join(code, "\n")))
+				code, inCode = nil, false
+			} else {
+				flush()
+				inCode = true
+			}
+			continue
+		}
+		if inCode {
+			code = append(code, strings.TrimRight(line, " \t"))
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			flush()
+
+		case strings.HasPrefix(trimmed, "#"):
+			flush()
+			level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+			text := strings.TrimSpace(trimmed[level:])
+			add(mdHeading, md.heading(level, text, width))
+
+		case trimmed == "---" || trimmed == "***" || trimmed == "___":
+			flush()
+			add(mdRule, th.Help.UnsetMarginTop().Render(strings.Repeat(th.Glyphs.Rule, min(width, 47))))
+
+		case strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* ") || strings.HasPrefix(trimmed, "+ "):
+			flush()
+			add(mdList, md.listItem(th.Glyphs.Bullet+" ", trimmed[2:], width))
+
+		case orderedItem.MatchString(trimmed):
+			flush()
+			marker := orderedItem.FindString(trimmed)
+			add(mdList, md.listItem(strings.TrimSpace(marker)+" ", trimmed[len(marker):], width))
+
+		case strings.HasPrefix(trimmed, ">"):
+			flush()
+			text := strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+			quote := trimLines(th.Quote.Width(max(width-2, 10)).Render(md.inline(text)))
+			bar := th.Quote.Render(th.Glyphs.Quote) + " "
+			add(mdQuote, prefixLines(quote, bar, bar))
+
+		default:
+			// Two trailing spaces or a backslash force a line break,
+			// otherwise lines of a paragraph flow together
+			if strings.HasSuffix(line, "  ") || strings.HasSuffix(trimmed, "\\") {
+				paragraph = append(paragraph, strings.TrimSuffix(trimmed, "\\")+"\n")
+			} else {
+				paragraph = append(paragraph, trimmed+" ")
+			}
+		}
+	}
+
+	// An unclosed fence still shows its code
+	if inCode {
+		add(mdCode, th.CodeBlock.Render(strings.Join(code, "\n")))
+	}
+	flush()
+
+	return joinBlocks(out)
+}
+
+type mdKind int
+
+const (
+	mdParagraph mdKind = iota
+	mdHeading
+	mdList
+	mdQuote
+	mdCode
+	mdRule
+)
+
+type mdBlock struct {
+	kind mdKind
+	text string
+}
+
+// joinBlocks puts a blank line between blocks, except between items of the
+// same list or quote and right under a heading
+func joinBlocks(blocks []mdBlock) string {
+	var b strings.Builder
+	for i, block := range blocks {
+		if i > 0 {
+			prev := blocks[i-1].kind
+			b.WriteString("\n")
+			tight := prev == mdHeading || (prev == block.kind && (prev == mdList || prev == mdQuote))
+			if !tight {
+				b.WriteString("\n")
+			}
+		}
+		b.WriteString(block.text)
+	}
+	return b.String()
+}
+
+func (md *markdown) heading(level int, text string, width int) string {
+	th := md.th
+	switch level {
+	case 1:
+		return th.Title.Width(width).Render(text)
+	case 2:
+		return th.Label.Render(text)
+	default:
+		return th.NavItemSelected.UnsetPadding().Render(text)
+	}
+}
+
+// listItem wraps an item with a hanging indent under the marker
+func (md *markdown) listItem(marker, text string, width int) string {
+	indent := "  " + strings.Repeat(" ", lipgloss.Width(marker))
+	body := md.wrapInline(text, max(width-len(indent), 10))
+	return prefixLines(body, "  "+md.th.NavArrow.Render(marker), indent)
+}
+
+// wrapInline renders inline markup and wraps the result to width. Hard
+// breaks are kept as newlines.
+func (md *markdown) wrapInline(text string, width int) string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(text, " \n"), "\n") {
+		line = strings.TrimSpace(line)
+		lines = append(lines, lipgloss.NewStyle().Width(width).Render(md.inline(line)))
+	}
+	return trimLines(strings.Join(lines, "\n"))
+}
+
+// trimLines drops the padding lipgloss adds when wrapping to a width
+func trimLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// prefixLines puts first before the first line and rest before the others
+func prefixLines(s, first, rest string) string {
+	lines := strings.Split(s, "\n")
+	for i := range lines {
+		if i == 0 {
+			lines[i] = first + lines[i]
+		} else {
+			lines[i] = rest + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// inline styles `code`, **bold**, *italics* and [links](url)
+func (md *markdown) inline(s string) string {
+	th := md.th
+	var b strings.Builder
+
+	for len(s) > 0 {
+		switch {
+		case s[0] == '`':
+			if end := strings.IndexByte(s[1:], '`'); end >= 0 {
+				b.WriteString(th.InlineCode.Render(s[1 : 1+end]))
+				s = s[end+2:]
+				continue
+			}
+
+		case strings.HasPrefix(s, "**"):
+			if end := strings.Index(s[2:], "**"); end > 0 {
+				b.WriteString(th.Strong.Render(s[2 : 2+end]))
+				s = s[end+4:]
+				continue
+			}
+
+		case s[0] == '*' && len(s) > 1 && s[1] != ' ':
+			if end := strings.IndexByte(s[1:], '*'); end > 0 {
+				b.WriteString(th.Emphasis.Render(s[1 : 1+end]))
+				s = s[end+2:]
+				continue
+			}
+
+		case s[0] == '[':
+			if m := linkPattern.FindStringSubmatch(s); m != nil {
+				b.WriteString(md.link(m[1], m[2]))
+				s = s[len(m[0]):]
+				continue
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(s)
+		b.WriteRune(r)
+		s = s[size:]
+	}
+
+	return b.String()
+}
+
+// link shows the link text, plus the target when it says something the
+// text doesn't. The text is an OSC 8 hyperlink where the terminal has them,
+// and the whole link is the mouse zone "link.<number>".
+func (md *markdown) link(text, target string) string {
+	th := md.th
+	n := len(md.links)
+	selected := n == md.selected
+	md.links = append(md.links, target)
+	name := fmt.Sprintf("link.%d", n)
+
+	// The arrow keeps the selection visible without colors or attributes
+	label := th.Link.Render(th.Hyperlink(target, text))
+	if selected {
+		label = th.NavArrow.Render(th.Glyphs.Arrow+" ") + th.Link.Reverse(true).Render(th.Hyperlink(target, text))
+	}
+	shown := linkText(target)
+	if shown == text {
+		return zone(name, label)
+	}
+	return zone(name, label+" "+th.Help.UnsetMarginTop().Render("("+shown+")"))
+}
+
+// linkText is a link target without its scheme
+func linkText(target string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(strings.TrimPrefix(target, "https://"), "http://"), "mailto:")
+}
@@ -0,0 +1,326 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pcstyle/ssh-server/internal/content"
+)
+
+// pagePrefix starts the View of every content page
+const pagePrefix = "page/"
+
+// pageView is the View of the page with slug
+func pageView(slug string) View {
+	return View(pagePrefix + slug)
+}
+
+func init() {
+	// One screen per page, hidden ones open with their unlock word
+	RegisterScreen("pages", 40, func(env ScreenEnv) []Screen {
+		var screens []Screen
+		for _, page := range env.Session.Pages.List(string(env.Tr.Locale())) {
+			screens = append(screens, NewPageModel(env.Session.Pages, page.Slug, env.Tr, env.Theme))
+		}
+		return screens
+	})
+}
+
+// PageModel shows a Markdown content page in a scrollable viewport
+type PageModel struct {
+	pages    *content.Library
+	slug     string
+	link     int    // selected link, -1 for none
+	qr       string // link shown as a QR code, "" when reading
+	viewport viewport.Model
+	width    int
+	height   int
+	tr       *Translator
+	theme    *Theme
+}
+
+// NewPageModel creates the view for the page with slug
+func NewPageModel(pages *content.Library, slug string, tr *Translator, theme *Theme) PageModel {
+	vp := viewport.New(0, 0)
+	vp.KeyMap = pageKeys.Scroll
+	return PageModel{
+		pages:    pages,
+		slug:     slug,
+		link:     -1,
+		viewport: vp,
+		tr:       tr,
+		theme:    theme,
+	}
+}
+
+// ID is "page/<slug>"
+func (m PageModel) ID() View {
+	return pageView(m.slug)
+}
+
+// Title is the page's menu label in the current language
+func (m PageModel) Title() string {
+	if page, ok := m.page(); ok {
+		return page.MenuTitle()
+	}
+	return m.slug
+}
+
+// Description is the page's, from its front matter
+func (m PageModel) Description() string {
+	page, _ := m.page()
+	return page.Description
+}
+
+// Hidden pages are left out of the menu until unlocked
+func (m PageModel) Hidden() bool {
+	page, _ := m.page()
+	return page.Hidden
+}
+
+// Unlock is the page's unlock word. A hidden page without one can only be
+// linked to.
+func (m PageModel) Unlock() []string {
+	page, _ := m.page()
+	if page.Unlock == "" {
+		return nil
+	}
+	return []string{page.Unlock}
+}
+
+// Init has nothing to do
+func (m PageModel) Init() tea.Cmd {
+	return nil
+}
+
+// Enter starts a new visit at the top with nothing selected. Back from
+// history the page is where it was left.
+func (m PageModel) Enter(how Arrival) (Screen, tea.Cmd) {
+	if how == ArriveNew {
+		m.link = -1
+		m.qr = ""
+		m.viewport.GotoTop()
+	}
+	return m, nil
+}
+
+// Leave has nothing to do
+func (m PageModel) Leave() (Screen, tea.Cmd) {
+	return m, nil
+}
+
+// Update handles scrolling and going back
+func (m PageModel) Update(msg tea.Msg) (Screen, tea.Cmd) {
+	switch typed := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = typed.Width
+		m.height = typed.Height
+		m.resize()
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.qr != "" {
+			// Any key goes back to the page
+			m.qr = ""
+			return m, nil
+		}
+		switch {
+		case key.Matches(typed, pageKeys.Back):
+			return m, func() tea.Msg { return BackMsg{} }
+		case key.Matches(typed, pageKeys.NextLink, pageKeys.PrevLink):
+			m.selectLink(key.Matches(typed, pageKeys.NextLink))
+			return m, nil
+		case key.Matches(typed, pageKeys.Copy):
+			return m, m.copyLink()
+		case key.Matches(typed, pageKeys.QR):
+			m.qr = m.qrTarget()
+			return m, nil
+		case key.Matches(typed, pageKeys.Top):
+			m.viewport.GotoTop()
+			return m, nil
+		case key.Matches(typed, pageKeys.Bottom):
+			m.viewport.SetContent(m.body())
+			m.viewport.GotoBottom()
+			return m, nil
+		}
+
+	case zoneMsg:
+		if m.qr != "" {
+			if typed.clicked() {
+				m.qr = ""
+			}
+			return m, nil
+		}
+		// Clicking a link selects and copies it
+		if i, ok := zoneIndex(typed.zone, "link."); ok && typed.clicked() && i < len(m.links()) {
+			m.link = i
+			return m, m.copyLink()
+		}
+		return m, nil
+	}
+
+	// Arrows, j/k, pgup/pgdown and the mouse wheel come from the viewport
+	var cmd tea.Cmd
+	m.viewport.SetContent(m.body())
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// copyLink copies the selected link, if there is one
+func (m PageModel) copyLink() tea.Cmd {
+	links := m.links()
+	if m.link < 0 || m.link >= len(links) {
+		return nil
+	}
+	text := copyTarget(links[m.link])
+	return func() tea.Msg { return CopyMsg{Text: text} }
+}
+
+// selectLink moves the link selection and scrolls it into view
+func (m *PageModel) selectLink(forward bool) {
+	n := len(m.links())
+	if n == 0 {
+		return
+	}
+	switch {
+	case m.link < 0 && forward:
+		m.link = 0
+	case m.link < 0:
+		m.link = n - 1
+	case forward:
+		m.link = (m.link + 1) % n
+	default:
+		m.link = (m.link + n - 1) % n
+	}
+
+	// The first line that changes when the link is highlighted is where it is
+	plain := strings.Split(renderMarkdown(m.pageBody(), m.theme, m.textWidth()), "\n")
+	body := m.body()
+	for i, line := range strings.Split(body, "\n") {
+		if i < len(plain) && line == plain[i] {
+			continue
+		}
+		m.viewport.SetContent(body)
+		if i < m.viewport.YOffset || i >= m.viewport.YOffset+m.viewport.Height {
+			m.viewport.SetYOffset(i - m.viewport.Height/2)
+		}
+		break
+	}
+}
+
+// KeyMap is what works on the page right now
+func (m PageModel) KeyMap() help.KeyMap {
+	return m.keys()
+}
+
+// keys hides the link keys on pages without links, and copying until a
+// link is picked
+func (m PageModel) keys() pageKeyMap {
+	keys := pageKeys
+	links := len(m.links())
+	keys.NextLink.SetEnabled(links > 0)
+	keys.PrevLink.SetEnabled(links > 0)
+	keys.QR.SetEnabled(m.qrTarget() != "")
+	keys.Copy.SetEnabled(m.link >= 0 && m.link < links)
+	return keys
+}
+
+// qrTarget is the link to show as a QR code: the selected one, otherwise
+// the first email address, since mailing is what people do from a phone
+func (m PageModel) qrTarget() string {
+	links := m.links()
+	if m.link >= 0 && m.link < len(links) {
+		return links[m.link]
+	}
+	for _, link := range links {
+		if strings.HasPrefix(link, "mailto:") {
+			return link
+		}
+	}
+	return ""
+}
+
+// links are the targets of the page's links, in order
+func (m PageModel) links() []string {
+	_, links := renderMarkdownLinks(m.pageBody(), m.theme, m.textWidth(), -1)
+	return links
+}
+
+// resize gives the viewport whatever the box, title and help leave over
+func (m *PageModel) resize() {
+	m.viewport.Width = m.textWidth()
+	m.viewport.Height = max(m.height-11, 3)
+}
+
+// textWidth is the room for page text inside the box
+func (m PageModel) textWidth() int {
+	if m.width == 0 {
+		return 76
+	}
+	return clamp(m.width-8, 20, 100)
+}
+
+func (m PageModel) page() (content.Page, bool) {
+	return m.pages.Page(m.slug, string(m.tr.Locale()))
+}
+
+func (m PageModel) pageBody() string {
+	page, _ := m.page()
+	return page.Body
+}
+
+// body renders the page Markdown for the current locale and width, with
+// the selected link highlighted
+func (m PageModel) body() string {
+	page, ok := m.page()
+	if !ok {
+		return m.theme.Error.Render(m.tr.T("page.missing"))
+	}
+	body, _ := renderMarkdownLinks(page.Body, m.theme, m.textWidth(), m.link)
+	return body
+}
+
+// View renders the title, the scrolled body and help
+func (m PageModel) View() string {
+	title := m.slug
+	if page, ok := m.page(); ok {
+		title = page.Title
+	}
+	if m.qr != "" {
+		return qrView(m.theme, m.tr, m.width, m.height, title, m.qr, linkText(m.qr))
+	}
+
+	var b strings.Builder
+	b.WriteString(m.theme.Title.Render(title))
+	b.WriteString("\n\n")
+
+	body := m.body()
+	keys := m.keys()
+	var status []string
+	if m.height == 0 {
+		// No size yet, show the whole page
+		b.WriteString(body)
+	} else {
+		vp := m.viewport
+		vp.SetContent(body)
+		if vp.TotalLineCount() > vp.Height {
+			b.WriteString(vp.View())
+			status = append(status, fmt.Sprintf("%d%%", int(vp.ScrollPercent()*100)))
+		} else {
+			b.WriteString(body)
+			// Nothing to scroll
+			for _, binding := range []*key.Binding{&keys.Scroll.Up, &keys.Scroll.Down, &keys.Top, &keys.Bottom} {
+				binding.SetEnabled(false)
+			}
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(keyHelp(m.theme, m.tr, m.width, keys, status...))
+
+	return m.theme.BoxFor(m.width).Render(b.String())
+}
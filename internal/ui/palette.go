@@ -0,0 +1,284 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// paletteItem is one thing the palette can do. msg is sent when it's
+// picked: a NavigateMsg for views, pages and games, or an action.
+type paletteItem struct {
+	title string
+	kind  string // catalog key, searched along with the title
+	msg   tea.Msg
+}
+
+// paletteClosedMsg closes the palette, running the picked item's msg if any
+type paletteClosedMsg struct {
+	run tea.Msg
+}
+
+// themeMsg switches to the palette called name
+type themeMsg struct {
+	name string
+}
+
+// PaletteModel is the ctrl+k command palette: a search box over every
+// place and action the visitor can reach, best matches first
+type PaletteModel struct {
+	input   textinput.Model
+	items   []paletteItem
+	matches []paletteItem
+	cursor  int
+	width   int
+	height  int
+	tr      *Translator
+	theme   *Theme
+}
+
+// NewPaletteModel creates a closed palette
+func NewPaletteModel(tr *Translator, theme *Theme) PaletteModel {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.CharLimit = 64
+	input.Width = 40
+	return PaletteModel{input: input, tr: tr, theme: theme}
+}
+
+// Open starts a new search over items
+func (m *PaletteModel) Open(items []paletteItem) tea.Cmd {
+	m.items = items
+	m.input.Placeholder = m.tr.T("palette.placeholder")
+	m.input.SetValue("")
+	m.filter()
+	return tea.Batch(m.input.Focus(), textinput.Blink)
+}
+
+// Update handles the search box and picking a match
+func (m PaletteModel) Update(msg tea.Msg) (PaletteModel, tea.Cmd) {
+	switch typed := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = typed.Width
+		m.height = typed.Height
+		if m.width > 0 {
+			m.input.Width = clamp(m.width-16, 10, 40)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(typed, paletteKeys.Close):
+			return m, func() tea.Msg { return paletteClosedMsg{} }
+		case key.Matches(typed, paletteKeys.Run):
+			if len(m.matches) == 0 {
+				return m, nil
+			}
+			run := m.matches[m.cursor].msg
+			return m, func() tea.Msg { return paletteClosedMsg{run: run} }
+		case key.Matches(typed, paletteKeys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case key.Matches(typed, paletteKeys.Down):
+			if m.cursor < len(m.matches)-1 {
+				m.cursor++
+			}
+			return m, nil
+		}
+
+	case tea.MouseMsg:
+		if dir, ok := wheel(typed); ok && len(m.matches) > 0 {
+			m.cursor = clamp(m.cursor+dir, 0, len(m.matches)-1)
+		}
+		return m, nil
+
+	case zoneMsg:
+		// A click runs the match, like enter. Hovering doesn't move the
+		// cursor, the list would scroll away under the pointer.
+		if i, ok := zoneIndex(typed.zone, "palette."); ok && typed.clicked() && i < len(m.matches) {
+			run := m.matches[i].msg
+			return m, func() tea.Msg { return paletteClosedMsg{run: run} }
+		}
+		return m, nil
+	}
+
+	query := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() != query {
+		m.filter()
+	}
+	return m, cmd
+}
+
+// filter ranks the items against the query; ties keep the menu order
+func (m *PaletteModel) filter() {
+	type scored struct {
+		item  paletteItem
+		score int
+	}
+	var found []scored
+	for _, item := range m.items {
+		if score, ok := fuzzyScore(m.input.Value(), item.title+" "+m.tr.T(item.kind)); ok {
+			found = append(found, scored{item, score})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].score > found[j].score })
+
+	m.matches = nil
+	for _, f := range found {
+		m.matches = append(m.matches, f.item)
+	}
+	m.cursor = 0
+}
+
+// View renders the search box and as many matches as the window fits
+func (m PaletteModel) View() string {
+	var b strings.Builder
+	b.WriteString(m.theme.Title.Render(m.tr.T("palette.title")))
+	b.WriteString("\n\n")
+	b.WriteString(m.theme.InputFocused.Render(m.input.View()))
+	b.WriteString("\n\n")
+
+	rows := 10
+	if m.height > 0 {
+		rows = clamp(m.height-16, 3, 10)
+	}
+	start := max(m.cursor-rows+1, 0)
+	end := min(start+rows, len(m.matches))
+
+	if len(m.matches) == 0 {
+		b.WriteString(m.theme.Help.UnsetMarginTop().Render(m.tr.T("palette.empty")))
+		b.WriteString("\n")
+	}
+	titleWidth := 0
+	for _, item := range m.matches[start:end] {
+		titleWidth = max(titleWidth, lipgloss.Width(item.title))
+	}
+	kindStyle := m.theme.Help.UnsetMarginTop()
+	for i := start; i < end; i++ {
+		item := m.matches[i]
+		cursor, style := "  ", m.theme.NavItem
+		if i == m.cursor {
+			cursor, style = m.theme.NavArrow.Render(m.theme.Glyphs.Arrow+" "), m.theme.NavItemSelected
+		}
+		pad := strings.Repeat(" ", titleWidth-lipgloss.Width(item.title)+2)
+		b.WriteString(zone(fmt.Sprintf("palette.%d", i), cursor+style.Render(item.title)+pad+kindStyle.Render(m.tr.T(item.kind))))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(keyHelp(m.theme, m.tr, m.width, m.KeyMap()))
+	return m.theme.BoxFor(m.width).Render(b.String())
+}
+
+// KeyMap is the palette's keymap; everything else is typed into the search
+func (m PaletteModel) KeyMap() help.KeyMap {
+	return paletteKeys
+}
+
+// paletteItems collects what the palette offers right now: the home menu
+// (unlocked pages and secrets included), whatever the reachable screens
+// add themselves, like the arcade games, the themes and copying the email
+func (m Model) paletteItems() []paletteItem {
+	home := m.homeModel()
+	var items []paletteItem
+	for _, item := range home.menu() {
+		kind := "palette.kind.view"
+		switch {
+		case item.isSecret:
+			kind = "palette.kind.secret"
+		case strings.HasPrefix(string(item.Target), pagePrefix):
+			kind = "palette.kind.page"
+		}
+		items = append(items, paletteItem{
+			title: home.itemTitle(item),
+			kind:  kind,
+			msg:   NavigateMsg{Target: item.Target},
+		})
+	}
+
+	// Secret screens keep their extras hidden until they're found
+	for _, s := range m.dir.Menu() {
+		if source, ok := m.screens[s.ID()].(paletteSource); ok {
+			items = append(items, source.paletteItems()...)
+		}
+	}
+
+	for _, name := range m.theme.Names() {
+		items = append(items, paletteItem{
+			title: m.tr.T("palette.theme", name),
+			kind:  "palette.kind.theme",
+			msg:   themeMsg{name: name},
+		})
+	}
+	items = append(items, paletteItem{
+		title: m.tr.T("palette.copy_email", ownerEmail),
+		kind:  "palette.kind.action",
+		msg:   CopyMsg{Text: ownerEmail},
+	})
+	return items
+}
+
+// fuzzyScore matches query against text as a subsequence, ignoring case,
+// spaces in the query and Polish diacritics. Higher is better: letters in
+// a row and letters starting a word count extra, so "snk" finds Snake and
+// "dzwiek" finds "dźwięk".
+func fuzzyScore(query, text string) (int, bool) {
+	var q []rune
+	for _, r := range foldRunes(query) {
+		if !unicode.IsSpace(r) {
+			q = append(q, r)
+		}
+	}
+	if len(q) == 0 {
+		return 0, true
+	}
+
+	t := foldRunes(text)
+	score, qi, prev := 0, 0, -2
+	for i, r := range t {
+		if qi == len(q) {
+			break
+		}
+		if r != q[qi] {
+			continue
+		}
+		score++
+		if prev == i-1 {
+			score += 3
+		}
+		if i == 0 || !unicode.IsLetter(t[i-1]) && !unicode.IsDigit(t[i-1]) {
+			score += 2
+		}
+		prev = i
+		qi++
+	}
+	if qi < len(q) {
+		return 0, false
+	}
+	return score, true
+}
+
+// polishFold maps Polish letters to the ones people type without AltGr
+var polishFold = map[rune]rune{
+	'ą': 'a', 'ć': 'c', 'ę': 'e', 'ł': 'l', 'ń': 'n', 'ó': 'o', 'ś': 's', 'ź': 'z', 'ż': 'z',
+}
+
+func foldRunes(s string) []rune {
+	runes := []rune(strings.ToLower(s))
+	for i, r := range runes {
+		if folded, ok := polishFold[r]; ok {
+			runes[i] = folded
+		}
+	}
+	return runes
+}
@@ -0,0 +1,414 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
+	"github.com/pcstyle/ssh-server/internal/projects"
+)
+
+// How often the badges are redrawn while the view is open. Checks run on
+// their own schedule in the monitor, this only picks up new results.
+const projectsRefresh = 5 * time.Second
+
+// ProjectsModel lists projects and shows one in detail with its live status
+type ProjectsModel struct {
+	projects []projects.Project
+	monitor  *projects.Monitor
+
+	cursor int
+	open   bool
+	link   int    // selected link on the detail page
+	opened string // link the visitor pressed enter on
+
+	// shots caches rendered screenshots by path, empty for ones that
+	// failed to load so they aren't retried every frame. Art depends on
+	// width, so it's cleared on resize.
+	shots map[string]string
+
+	// tick tells the current refresh loop apart from ones started on
+	// earlier visits
+	tick int
+
+	viewport viewport.Model
+	width    int
+	height   int
+	tr       *Translator
+	theme    *Theme
+}
+
+// projectsTickMsg redraws status badges
+type projectsTickMsg struct{ id int }
+
+func init() {
+	RegisterScreen("projects", 30, func(env ScreenEnv) []Screen {
+		if len(env.Session.Projects) == 0 {
+			return nil
+		}
+		return []Screen{NewProjectsModel(env.Session, env.Tr, env.Theme)}
+	})
+}
+
+// NewProjectsModel creates the projects browser
+func NewProjectsModel(session Session, tr *Translator, theme *Theme) ProjectsModel {
+	vp := viewport.New(0, 0)
+	vp.KeyMap = projectKeys.Scroll
+	return ProjectsModel{
+		projects: session.Projects,
+		monitor:  session.Monitor,
+		shots:    make(map[string]string),
+		viewport: vp,
+		tr:       tr,
+		theme:    theme,
+	}
+}
+
+// ID identifies the projects browser
+func (m ProjectsModel) ID() View {
+	return ViewProjects
+}
+
+// Title is the menu entry
+func (m ProjectsModel) Title() string {
+	return m.tr.T("menu.projects.title")
+}
+
+// Description is shown next to the menu entry
+func (m ProjectsModel) Description() string {
+	return m.tr.T("menu.projects.desc")
+}
+
+// Hidden is false, the browser is only built when there are projects
+func (m ProjectsModel) Hidden() bool {
+	return false
+}
+
+// Unlock is empty, the browser is never hidden
+func (m ProjectsModel) Unlock() []string {
+	return nil
+}
+
+// Init has nothing to do, badges refresh from Enter
+func (m ProjectsModel) Init() tea.Cmd {
+	return nil
+}
+
+// Enter starts refreshing badges, on the list unless we're back from
+// history to a project's details
+func (m ProjectsModel) Enter(how Arrival) (Screen, tea.Cmd) {
+	if how == ArriveNew {
+		m.open = false
+	}
+	m.tick++
+	return m, m.refresh()
+}
+
+// Leave stops the badge refresh loop
+func (m ProjectsModel) Leave() (Screen, tea.Cmd) {
+	m.tick++
+	return m, nil
+}
+
+// crumb is the name of the open project
+func (m ProjectsModel) crumb() string {
+	if !m.open || m.cursor >= len(m.projects) {
+		return ""
+	}
+	return m.projects[m.cursor].Name
+}
+
+func (m ProjectsModel) refresh() tea.Cmd {
+	id := m.tick
+	return tea.Tick(projectsRefresh, func(time.Time) tea.Msg {
+		return projectsTickMsg{id: id}
+	})
+}
+
+// Update handles the list, the detail page and badge refreshes
+func (m ProjectsModel) Update(msg tea.Msg) (Screen, tea.Cmd) {
+	switch typed := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = typed.Width
+		m.height = typed.Height
+		m.shots = make(map[string]string)
+		m.loadShots()
+		m.resize()
+		return m, nil
+
+	case projectsTickMsg:
+		if typed.id != m.tick {
+			return m, nil
+		}
+		return m, m.refresh()
+
+	case tea.KeyMsg:
+		if m.open {
+			return m.updateDetail(typed)
+		}
+		return m.updateList(typed)
+	}
+
+	if m.open {
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m ProjectsModel) updateList(msg tea.KeyMsg) (ProjectsModel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, projectsKeys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(msg, projectsKeys.Down):
+		if m.cursor < len(m.projects)-1 {
+			m.cursor++
+		}
+	case key.Matches(msg, projectsKeys.Open):
+		if len(m.projects) == 0 {
+			return m, nil
+		}
+		m.open = true
+		m.link = 0
+		m.opened = ""
+		m.loadShots()
+		m.viewport.SetContent(m.detailBody())
+		m.viewport.GotoTop()
+	case key.Matches(msg, projectsKeys.Back):
+		return m, func() tea.Msg { return BackMsg{} }
+	}
+	return m, nil
+}
+
+func (m ProjectsModel) updateDetail(msg tea.KeyMsg) (ProjectsModel, tea.Cmd) {
+	links := m.projects[m.cursor].Links
+	switch {
+	case key.Matches(msg, projectKeys.Close):
+		m.open = false
+		return m, nil
+	case key.Matches(msg, projectKeys.NextLink):
+		if len(links) > 0 {
+			m.link = (m.link + 1) % len(links)
+		}
+		return m, nil
+	case key.Matches(msg, projectKeys.PrevLink):
+		if len(links) > 0 {
+			m.link = (m.link + len(links) - 1) % len(links)
+		}
+		return m, nil
+	case key.Matches(msg, projectKeys.OpenLink):
+		if m.link < len(links) {
+			m.opened = links[m.link].URL
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.viewport.SetContent(m.detailBody())
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// KeyMap is what works on the list or the open project right now
+func (m ProjectsModel) KeyMap() help.KeyMap {
+	if !m.open {
+		keys := projectsKeys
+		keys.Up.SetEnabled(len(m.projects) > 1)
+		keys.Down.SetEnabled(len(m.projects) > 1)
+		keys.Open.SetEnabled(len(m.projects) > 0)
+		return keys
+	}
+
+	keys := projectKeys
+	hasLinks := m.cursor < len(m.projects) && len(m.projects[m.cursor].Links) > 0
+	keys.NextLink.SetEnabled(hasLinks)
+	keys.PrevLink.SetEnabled(hasLinks)
+	keys.OpenLink.SetEnabled(hasLinks)
+	return keys
+}
+
+// resize leaves room for the title, links and help around the detail text
+func (m *ProjectsModel) resize() {
+	m.viewport.Width = m.textWidth()
+	m.viewport.Height = max(m.height-16, 3)
+	if m.open {
+		m.viewport.SetContent(m.detailBody())
+	}
+}
+
+// loadShots reads and renders the open project's screenshots that aren't
+// cached yet. It runs on open and resize, View only draws what's here.
+func (m *ProjectsModel) loadShots() {
+	if !m.open || m.cursor >= len(m.projects) {
+		return
+	}
+	for _, path := range m.projects[m.cursor].Screenshots {
+		if _, ok := m.shots[path]; ok {
+			continue
+		}
+		img, err := projects.LoadImage(path)
+		if err != nil {
+			log.Warn("Failed to load screenshot", "path", path, "error", err)
+			m.shots[path] = ""
+			continue
+		}
+		m.shots[path] = asciiArt(img, m.textWidth())
+	}
+}
+
+// textWidth is the room for text inside the box
+func (m ProjectsModel) textWidth() int {
+	if m.width == 0 {
+		return 76
+	}
+	return clamp(m.width-8, 20, 100)
+}
+
+func (m ProjectsModel) project(i int) projects.Project {
+	return m.projects[i].Localized(string(m.tr.Locale()))
+}
+
+// badge renders the live status of url
+func (m ProjectsModel) badge(url string) string {
+	status := m.monitor.Status(url)
+	dot := m.theme.Glyphs.Dot
+	switch status.State {
+	case projects.StateUp:
+		return m.theme.Success.UnsetPadding().Render(dot + " " + m.tr.T("projects.up", status.Latency.Milliseconds()))
+	case projects.StateDown:
+		return m.theme.Error.UnsetPadding().Render(dot + " " + m.tr.T("projects.down"))
+	default:
+		return m.theme.Help.UnsetMarginTop().Render(m.theme.Glyphs.Crumb + " " + m.tr.T("projects.checking"))
+	}
+}
+
+// View renders the project list or the open project
+func (m ProjectsModel) View() string {
+	if m.open && m.cursor < len(m.projects) {
+		return m.detailView()
+	}
+
+	var b strings.Builder
+	b.WriteString(m.theme.Title.Render(m.tr.T("menu.projects.title")))
+	b.WriteString("\n\n")
+
+	if len(m.projects) == 0 {
+		b.WriteString(m.theme.Help.UnsetMarginTop().Render(m.tr.T("projects.empty")))
+		b.WriteString("\n")
+	}
+
+	descStyle := m.theme.Help.UnsetMarginTop()
+	for i := range m.projects {
+		p := m.project(i)
+		cursor := "  "
+		itemStyle := m.theme.NavItem
+		if i == m.cursor {
+			cursor = m.theme.NavArrow.Render(m.theme.Glyphs.Arrow + " ")
+			itemStyle = m.theme.NavItemSelected
+		}
+
+		line := cursor + itemStyle.Render(p.Name) + " " + m.badge(p.URL)
+		switch {
+		case p.Tagline == "":
+		case !compact(m.width):
+			line += " - " + descStyle.Render(p.Tagline)
+		case i == m.cursor:
+			line += "\n      " + descStyle.Render(p.Tagline)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(keyHelp(m.theme, m.tr, m.width, m.KeyMap()))
+
+	return m.theme.BoxFor(m.width).Render(b.String())
+}
+
+// detailBody is the scrolling part of the detail page: description, stack
+// and screenshots
+func (m ProjectsModel) detailBody() string {
+	p := m.project(m.cursor)
+	width := m.textWidth()
+
+	var b strings.Builder
+	if p.Description != "" {
+		b.WriteString(renderMarkdown(p.Description, m.theme, width))
+		b.WriteString("\n\n")
+	}
+
+	if len(p.Stack) > 0 {
+		b.WriteString(m.theme.Label.Render(m.tr.T("projects.stack")))
+		chips := make([]string, len(p.Stack))
+		for i, tech := range p.Stack {
+			chips[i] = m.theme.InlineCode.Render(tech)
+		}
+		b.WriteString(strings.Join(chips, m.theme.Help.UnsetMarginTop().Render(" "+m.theme.Glyphs.Bullet+" ")))
+		b.WriteString("\n")
+	}
+
+	for _, path := range p.Screenshots {
+		art := m.shots[path]
+		if art == "" {
+			continue // not loaded or broken, see loadShots
+		}
+		b.WriteString("\n")
+		b.WriteString(m.theme.Help.UnsetMarginTop().Render(art))
+		b.WriteString("\n")
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func (m ProjectsModel) detailView() string {
+	p := m.project(m.cursor)
+
+	var b strings.Builder
+	b.WriteString(m.theme.Title.Render(p.Name))
+	b.WriteString("\n")
+	meta := m.badge(p.URL)
+	if p.Tagline != "" {
+		meta = m.theme.Help.UnsetMarginTop().Render(p.Tagline) + "  " + meta
+	}
+	b.WriteString(meta)
+	b.WriteString("\n\n")
+
+	body := m.detailBody()
+	vp := m.viewport
+	vp.SetContent(body)
+	if m.height == 0 || vp.TotalLineCount() <= vp.Height {
+		b.WriteString(body)
+	} else {
+		b.WriteString(vp.View())
+	}
+	b.WriteString("\n\n")
+
+	// Links, one selectable at a time
+	for i, link := range p.Links {
+		cursor := "  "
+		itemStyle := m.theme.NavItem.UnsetPadding()
+		if i == m.link {
+			cursor = m.theme.NavArrow.Render(m.theme.Glyphs.Arrow + " ")
+			itemStyle = m.theme.NavItemSelected.UnsetPadding()
+		}
+		b.WriteString(fmt.Sprintf("%s%s %s\n", cursor, itemStyle.Render(link.Label), m.theme.Help.UnsetMarginTop().Render(link.URL)))
+	}
+
+	if m.opened != "" {
+		b.WriteString("\n")
+		b.WriteString(m.theme.Label.Render(m.tr.T("projects.open")))
+		b.WriteString(m.theme.Link.Render(m.theme.Hyperlink(m.opened, m.opened)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(keyHelp(m.theme, m.tr, m.width, m.KeyMap()))
+
+	return m.theme.BoxFor(m.width).Render(b.String())
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pcstyle/ssh-server/internal/projects"
+)
+
+func TestScreenshotsLoadOnOpen(t *testing.T) {
+	dir := t.TempDir()
+	shot := filepath.Join(dir, "shot.png")
+	img := image.NewGray(image.Rect(0, 0, 8, 8))
+	for x := range 4 {
+		for y := range 8 {
+			img.SetGray(x, y, color.Gray{Y: 255})
+		}
+	}
+	f, err := os.Create(shot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	broken := filepath.Join(dir, "missing.png")
+
+	session := Session{Projects: []projects.Project{{Name: "Demo", Screenshots: []string{broken, shot}}}}
+	theme := NewTheme(lipgloss.NewRenderer(io.Discard), nil, Capabilities{})
+	var screen Screen = NewProjectsModel(session, NewTranslator(LocaleEN), theme)
+	screen, _ = screen.Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	m := screen.(ProjectsModel)
+	if art, ok := m.shots[broken]; !ok || art != "" {
+		t.Errorf("broken screenshot cached as %q, %v", art, ok)
+	}
+	if m.shots[shot] == "" {
+		t.Fatal("screenshot wasn't loaded on open")
+	}
+
+	// Rendering only draws what's cached, the files aren't read again
+	os.Remove(shot)
+	before := len(m.shots)
+	if view := m.View(); view == "" || len(m.shots) != before {
+		t.Fatalf("view changed the cache: %d entries, was %d", len(m.shots), before)
+	}
+	line, _, _ := strings.Cut(m.shots[shot], "\n")
+	if body := m.detailBody(); !strings.Contains(body, line) {
+		t.Error("cached screenshot missing from the detail page")
+	}
+}
@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pcstyle/ssh-server/internal/qr"
+)
+
+// Who the contact card QR on the receipt is for
+const (
+	ownerName  = "Adam Krupa"
+	ownerEmail = "adamkrupa@tuta.io"
+	ownerURL   = "https://pcstyle.dev"
+)
+
+// Light modules around the code; the spec asks for 4, phones cope with 2
+const qrQuietZone = 2
+
+// renderQR draws text as a QR code that fits in width x height cells, or
+// returns false when it doesn't (a zero size means unlimited).
+//
+// Unicode terminals get two modules per cell with half blocks. Light
+// modules are the drawn ones, pinned to white on black when the terminal
+// has colors; without colors they take the default foreground, which is
+// light on the usual dark terminal and gives an inverted code elsewhere,
+// something phone cameras read fine. The ASCII tier draws every module as
+// "##", one row each, since two characters per row is about square.
+func renderQR(text string, th *Theme, width, height int) (string, bool) {
+	code, err := qr.Encode([]byte(text))
+	if err != nil {
+		return "", false
+	}
+	light := func(x, y int) bool { return !code.Dark(x-qrQuietZone, y-qrQuietZone) }
+	size := code.Size + 2*qrQuietZone
+
+	var lines []string
+	if th.Caps.ASCII {
+		if (width > 0 && size*2 > width) || (height > 0 && size > height) {
+			return "", false
+		}
+		for y := 0; y < size; y++ {
+			var b strings.Builder
+			for x := 0; x < size; x++ {
+				if light(x, y) {
+					b.WriteString("##")
+				} else {
+					b.WriteString("  ")
+				}
+			}
+			lines = append(lines, b.String())
+		}
+		return strings.Join(lines, "\n"), true
+	}
+
+	rows := (size + 1) / 2
+	if (width > 0 && size > width) || (height > 0 && rows > height) {
+		return "", false
+	}
+	style := th.renderer.NewStyle()
+	if th.Caps.Color != TierMono {
+		style = style.Foreground(lipgloss.Color("15")).Background(lipgloss.Color("0"))
+	}
+	for row := 0; row < rows; row++ {
+		var b strings.Builder
+		for x := 0; x < size; x++ {
+			// The row past the bottom of an odd sized code is quiet zone too
+			top, bottom := light(x, row*2), row*2+1 >= size || light(x, row*2+1)
+			switch {
+			case top && bottom:
+				b.WriteString("█")
+			case top:
+				b.WriteString("▀")
+			case bottom:
+				b.WriteString("▄")
+			default:
+				b.WriteString(" ")
+			}
+		}
+		lines = append(lines, style.Render(b.String()))
+	}
+	return strings.Join(lines, "\n"), true
+}
+
+// qrView is the QR screen shared by the pages and the contact receipt:
+// title, the code as big as the terminal allows and a caption saying what
+// it holds. Terminals too small for the code get the caption on its own.
+func qrView(th *Theme, tr *Translator, width, height int, title, text, caption string) string {
+	box := th.BoxFor(width)
+	room := width - box.GetHorizontalFrameSize() - 4
+	frame := func(body, note string) string {
+		noteStyle := th.Help.UnsetMarginTop()
+		if width > 0 && lipgloss.Width(note) > room {
+			noteStyle = noteStyle.Width(max(room, 1))
+		}
+		var b strings.Builder
+		b.WriteString(th.Title.Render(title))
+		b.WriteString("\n\n")
+		if body != "" {
+			b.WriteString(body)
+			b.WriteString("\n")
+		}
+		b.WriteString(noteStyle.Render(note))
+		b.WriteString("\n")
+		b.WriteString(th.Label.Render(caption))
+		b.WriteString("\n")
+		b.WriteString(th.RenderHelp(width, tr.T("qr.help")))
+		return box.Render(b.String())
+	}
+
+	// The code gets whatever the frame leaves over
+	codeWidth, codeHeight := 0, 0
+	if width > 0 {
+		codeWidth = max(room, 1)
+	}
+	if height > 0 {
+		codeHeight = max(height-lipgloss.Height(frame("", tr.T("qr.scan"))), 1)
+	}
+	if code, ok := renderQR(text, th, codeWidth, codeHeight); ok {
+		return frame(code, tr.T("qr.scan"))
+	}
+	return frame("", tr.T("qr.too_small"))
+}
+
+// vCard builds a contact card phones can save straight to the address book
+func vCard(name, email, url, note string) string {
+	lines := []string{"BEGIN:VCARD", "VERSION:3.0", "FN:" + name}
+	if email != "" {
+		lines = append(lines, "EMAIL:"+email)
+	}
+	if url != "" {
+		lines = append(lines, "URL:"+url)
+	}
+	if note != "" {
+		lines = append(lines, "NOTE:"+note)
+	}
+	lines = append(lines, "END:VCARD")
+	return strings.Join(lines, "\r\n")
+}
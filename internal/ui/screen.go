@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pcstyle/ssh-server/internal/api"
+)
+
+// View identifies a screen. The built-in ones have constants, content
+// pages are "page/<slug>" (see pageView).
+type View string
+
+const (
+	ViewHome     View = "home"
+	ViewContact  View = "contact"
+	ViewArcade   View = "arcade"
+	ViewSecrets  View = "secrets"
+	ViewInbox    View = "inbox"
+	ViewBlog     View = "blog"
+	ViewProjects View = "projects"
+
+	// ViewExit isn't a screen, navigating to it ends the session
+	ViewExit View = "exit"
+)
+
+// Screen is a view the app can navigate to. Screens are values like any
+// Bubble Tea model: every method that changes one returns the new copy.
+//
+// Title, Description, Hidden and Unlock describe the screen to the menu
+// and the palette, which ask the copy built when the session started. They
+// can't depend on anything the screen changes about itself later.
+type Screen interface {
+	ID() View
+	Init() tea.Cmd
+	Update(msg tea.Msg) (Screen, tea.Cmd)
+	View() string
+	KeyMap() help.KeyMap
+
+	// Enter runs when the screen is shown, Leave when another one is
+	Enter(how Arrival) (Screen, tea.Cmd)
+	Leave() (Screen, tea.Cmd)
+
+	// Title and Description are the menu entry, translated
+	Title() string
+	Description() string
+
+	// Hidden screens stay out of the menu and palette until one of the
+	// Unlock words is typed on the home screen
+	Hidden() bool
+	Unlock() []string
+}
+
+// Screens can also take part in these, each is optional
+type (
+	// typer is taking text, so ?, : and q are just characters
+	typer interface{ typing() bool }
+
+	// crumber has something open inside it for the breadcrumb bar
+	crumber interface{ crumb() string }
+
+	// player can start one of its games straight from a NavigateMsg
+	player interface {
+		Play(game string) (Screen, tea.Cmd)
+	}
+
+	// paletteSource adds its own entries to the palette, once reachable
+	paletteSource interface{ paletteItems() []paletteItem }
+)
+
+// ScreenEnv is what a session's screens are built from
+type ScreenEnv struct {
+	API     *api.Client
+	Session Session
+	Tr      *Translator
+	Theme   *Theme
+}
+
+// ScreenBuilder makes a registration's screens for one session. Returning
+// none is fine, like the blog without a feed.
+type ScreenBuilder func(env ScreenEnv) []Screen
+
+type registration struct {
+	name  string
+	order int
+	build ScreenBuilder
+}
+
+var registry []registration
+
+// RegisterScreen adds screens to every session, usually from an init
+// function next to the screen. order places them in the menu, lower first;
+// name is how Session.Disabled turns them off.
+func RegisterScreen(name string, order int, build ScreenBuilder) {
+	registry = append(registry, registration{name: name, order: order, build: build})
+}
+
+// buildScreens runs every enabled registration in menu order
+func buildScreens(env ScreenEnv) []Screen {
+	regs := slices.Clone(registry)
+	sort.SliceStable(regs, func(i, j int) bool { return regs[i].order < regs[j].order })
+
+	var screens []Screen
+	for _, reg := range regs {
+		if slices.Contains(env.Session.Disabled, reg.name) {
+			continue
+		}
+		screens = append(screens, reg.build(env)...)
+	}
+	return screens
+}
+
+// Directory lists a session's screens in menu order and remembers which
+// hidden ones the visitor unlocked. Like the Translator it's shared by
+// pointer, so the home menu, the palette and the app agree.
+type Directory struct {
+	screens  []Screen
+	unlocked map[View]bool
+}
+
+func newDirectory(screens []Screen) *Directory {
+	return &Directory{screens: screens, unlocked: make(map[View]bool)}
+}
+
+// Find returns the screen with id, as it was built
+func (d *Directory) Find(id View) (Screen, bool) {
+	for _, s := range d.screens {
+		if s.ID() == id {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// Reachable reports whether id is in the menu right now
+func (d *Directory) Reachable(id View) bool {
+	s, ok := d.Find(id)
+	return ok && (!s.Hidden() || d.unlocked[id])
+}
+
+// Secret reports whether id is a hidden screen the visitor unlocked
+func (d *Directory) Secret(id View) bool {
+	return d.unlocked[id]
+}
+
+// Menu lists the reachable screens, unlocked secrets last
+func (d *Directory) Menu() []Screen {
+	var open, secret []Screen
+	for _, s := range d.screens {
+		switch {
+		case d.unlocked[s.ID()]:
+			secret = append(secret, s)
+		case !s.Hidden():
+			open = append(open, s)
+		}
+	}
+	return append(open, secret...)
+}
+
+// Unlock unlocks the hidden screens with an Unlock word in typed and
+// returns them, or nil if there's nothing new
+func (d *Directory) Unlock(typed string) []View {
+	var found []View
+	for _, s := range d.screens {
+		if !s.Hidden() || d.unlocked[s.ID()] {
+			continue
+		}
+		for _, word := range s.Unlock() {
+			if word != "" && strings.Contains(typed, word) {
+				d.unlocked[s.ID()] = true
+				found = append(found, s.ID())
+				break
+			}
+		}
+	}
+	return found
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"image"
+	"strings"
+)
+
+// asciiRamp goes from empty to dense; on a dark background dense reads as bright
+const asciiRamp = " .:-=+*#%@"
+
+// asciiArt draws img width characters wide. Terminal cells are about twice
+// as tall as they are wide, so every row covers two pixel rows' worth.
+func asciiArt(img image.Image, width int) string {
+	bounds := img.Bounds()
+	if width <= 0 || bounds.Dx() == 0 || bounds.Dy() == 0 {
+		return ""
+	}
+	width = min(width, bounds.Dx())
+	height := max(bounds.Dy()*width/bounds.Dx()/2, 1)
+
+	var b strings.Builder
+	for row := 0; row < height; row++ {
+		y0 := bounds.Min.Y + row*bounds.Dy()/height
+		y1 := max(bounds.Min.Y+(row+1)*bounds.Dy()/height, y0+1)
+		for col := 0; col < width; col++ {
+			x0 := bounds.Min.X + col*bounds.Dx()/width
+			x1 := max(bounds.Min.X+(col+1)*bounds.Dx()/width, x0+1)
+			level := luminance(img, x0, y0, x1, y1) * (len(asciiRamp) - 1) / 0xffff
+			b.WriteByte(asciiRamp[level])
+		}
+		if row < height-1 {
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+// luminance averages the brightness of a block of pixels, 0 to 0xffff
+func luminance(img image.Image, x0, y0, x1, y1 int) int {
+	var sum, n int
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			sum += int(299*r+587*g+114*b) / 1000
+			n++
+		}
+	}
+	return sum / n
+}
@@ -5,58 +5,115 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pcstyle/ssh-server/internal/content"
 )
 
-// SecretsModel is just vibes, trochę dziennik, trochę spam
+// SecretsModel is just vibes, trochę dziennik, trochę spam.
+// Wpisy to pliki .md z content/secrets, kolejność z front matter
 type SecretsModel struct {
 	index       int
-	entries     []secretEntry
+	entries     *content.Library
 	statusFlash string
-	lastTick    time.Time
 	width       int
 	height      int
+	seeds       Seeds
+	tr          *Translator
+	theme       *Theme
 }
 
-type secretEntry struct {
-	title string
-	body  []string
+func init() {
+	RegisterScreen("secrets", 91, func(env ScreenEnv) []Screen {
+		return []Screen{NewSecretsModel(env.Session.Secrets, env.Session.Seeds, env.Tr, env.Theme)}
+	})
 }
 
-// NewSecretsModel spawns the list
-func NewSecretsModel() SecretsModel {
+// NewSecretsModel spawns the list, seeds losują od którego wpisu startujemy
+func NewSecretsModel(entries *content.Library, seeds Seeds, tr *Translator, theme *Theme) SecretsModel {
 	return SecretsModel{
-		entries: buildSecretEntries(),
+		entries: entries,
+		seeds:   seeds,
+		tr:      tr,
+		theme:   theme,
 	}
 }
 
-// Enter resets the view state
-func (m *SecretsModel) Enter() tea.Cmd {
-	m.index = time.Now().Nanosecond() % len(m.entries)
-	m.statusFlash = "otwieram dziennik... chwila"
-	return tea.Tick(280*time.Millisecond, func(time.Time) tea.Msg {
-		return secretsBlinkMsg(time.Now())
+// ID dziennika
+func (m SecretsModel) ID() View {
+	return ViewSecrets
+}
+
+// Title "???" w menu
+func (m SecretsModel) Title() string {
+	return m.tr.T("menu.secrets.title")
+}
+
+// Description do menu
+func (m SecretsModel) Description() string {
+	return m.tr.T("menu.secrets.desc")
+}
+
+// Hidden, odblokowuje się razem z arcade
+func (m SecretsModel) Hidden() bool {
+	return true
+}
+
+// Unlock te same słowa co arcade
+func (m SecretsModel) Unlock() []string {
+	return []string{"snake", "games"}
+}
+
+// Init nic
+func (m SecretsModel) Init() tea.Cmd {
+	return nil
+}
+
+// list zwraca wpisy w aktualnym języku
+func (m SecretsModel) list() []content.Page {
+	return m.entries.List(string(m.tr.Locale()))
+}
+
+// Enter resets the view state, unless we're back from history, then the
+// same wpis stays open
+func (m SecretsModel) Enter(how Arrival) (Screen, tea.Cmd) {
+	if how == ArriveHistory {
+		return m, nil
+	}
+	m.index = 0
+	if total := len(m.list()); total > 0 {
+		m.index = newRand(m.seeds).Intn(total)
+	}
+	m.statusFlash = "secrets.status.opening"
+	return m, tea.Tick(280*time.Millisecond, func(t time.Time) tea.Msg {
+		return secretsBlinkMsg(t)
 	})
 }
 
+// Leave: nic do sprzątania
+func (m SecretsModel) Leave() (Screen, tea.Cmd) {
+	return m, nil
+}
+
 // Update handles events
-func (m SecretsModel) Update(msg tea.Msg) (SecretsModel, tea.Cmd) {
+func (m SecretsModel) Update(msg tea.Msg) (Screen, tea.Cmd) {
 	switch typed := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = typed.Width
 		m.height = typed.Height
 	case tea.KeyMsg:
-		switch typed.String() {
-		case "left", "h", "k":
-			m.index = wrapSecretsIndex(m.index-1, len(m.entries))
-			m.statusFlash = "cofnąłem wpis • chill"
-		case "right", "l", "j", " ":
-			m.index = wrapSecretsIndex(m.index+1, len(m.entries))
-			m.statusFlash = "next log... don't judge"
-		case "enter":
-			m.index = wrapSecretsIndex(m.index+1, len(m.entries))
-			m.statusFlash = "skipping bo tak"
-		case "esc", "q":
+		switch {
+		case key.Matches(typed, secretsKeys.Prev):
+			m.index = wrapSecretsIndex(m.index-1, len(m.list()))
+			m.statusFlash = "secrets.status.prev"
+		case key.Matches(typed, secretsKeys.Next):
+			m.index = wrapSecretsIndex(m.index+1, len(m.list()))
+			m.statusFlash = "secrets.status.next"
+		case key.Matches(typed, secretsKeys.Skip):
+			m.index = wrapSecretsIndex(m.index+1, len(m.list()))
+			m.statusFlash = "secrets.status.skip"
+		case key.Matches(typed, secretsKeys.Back):
 			return m, func() tea.Msg { return BackMsg{} }
 		}
 	case secretsBlinkMsg:
@@ -67,26 +124,38 @@ func (m SecretsModel) Update(msg tea.Msg) (SecretsModel, tea.Cmd) {
 
 // View prints the current entry
 func (m SecretsModel) View() string {
-	entry := m.entries[m.index]
+	entries := m.list()
+	if len(entries) == 0 {
+		return m.theme.BoxFor(m.width).Render(m.theme.RenderHelp(m.width, m.tr.T("secrets.empty")))
+	}
+
+	entry := entries[wrapSecretsIndex(m.index, len(entries))]
 	var b strings.Builder
 
-	header := fmt.Sprintf("log %02d :: %s", m.index+1, entry.title)
-	b.WriteString(TitleStyle.Render(header))
+	header := fmt.Sprintf("log %02d :: %s", m.index+1, entry.Title)
+	b.WriteString(m.theme.Title.Render(header))
 	b.WriteString("\n\n")
 
-	for _, line := range entry.body {
-		b.WriteString(NavItemStyle.Render(line))
-		b.WriteString("\n")
+	width := 76
+	if m.width > 0 {
+		width = clamp(m.width-8, 20, 76)
 	}
+	b.WriteString(renderMarkdown(entry.Body, m.theme, width))
+	b.WriteString("\n")
 
 	b.WriteString("\n")
-	b.WriteString(HelpStyle.Render(" ←/→ (hjkl też) zmienia wpis • enter skip • esc wraca "))
+	b.WriteString(keyHelp(m.theme, m.tr, m.width, m.KeyMap()))
 	if m.statusFlash != "" {
 		b.WriteString("\n")
-		b.WriteString(HelpStyle.Render(m.statusFlash))
+		b.WriteString(m.theme.RenderHelp(m.width, m.tr.T(m.statusFlash)))
 	}
 
-	return BoxStyle.Render(b.String())
+	return m.theme.BoxFor(m.width).Render(b.String())
+}
+
+// KeyMap to klawisze dziennika
+func (m SecretsModel) KeyMap() help.KeyMap {
+	return secretsKeys
 }
 
 func wrapSecretsIndex(idx, total int) int {
@@ -103,56 +172,3 @@ func wrapSecretsIndex(idx, total int) int {
 }
 
 type secretsBlinkMsg time.Time
-
-func buildSecretEntries() []secretEntry {
-	return []secretEntry{
-		{
-			title: "ssh onboarding chaos",
-			body: []string{
-				"• lesson #1: ludzie kochają wejścia ascii, so keep them.",
-				"• lesson #2: zostaw małe bugi, wyglądają na autentyczne.",
-				"• note: tak, snake został napisany za późno w nocy.",
-			},
-		},
-		{
-			title: "todo? maybe?",
-			body: []string{
-				"- build bubble tea ui for fridge? czemu nie.",
-				"- zakończyć shader o 3 w nocy. again.",
-				"- find keyboard that nie hałasuje aż tak.",
-			},
-		},
-		{
-			title: "fave commands of the week",
-			body: []string{
-				"`curl wttr.in` // bo pogoda ma vibe",
-				"`rg \"ugh\"` // sprawdzam gdzie narzekałem w kodzie",
-				"`ssh` // obvious",
-			},
-		},
-		{
-			title: "konserwy audio",
-			body: []string{
-				"-> synthwave w tle, bo inaczej snake zasypia",
-				"-> czasem white noise, seriously",
-				"-> 3AM playlist: alt-J, nosowska, jak zwykle mix totalny",
-			},
-		},
-		{
-			title: "pcstyle lore dump",
-			body: []string{
-				"1. pierwsze portfolio było css zrobione w notatniku.",
-				"2. potem generative art, bo czemu nie.",
-				"3. teraz można mnie pingować przez ssh, wild.",
-			},
-		},
-		{
-			title: "easter egg roadmap",
-			body: []string{
-				"[ ] ascii art generator (jakieś glitchy logo).",
-				"[x] snake ale taki w 2 kolorach.",
-				"[ ] hidden chat bot? maybe.",
-			},
-		},
-	}
-}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"io"
+
+	"github.com/pcstyle/ssh-server/internal/arena"
+	"github.com/pcstyle/ssh-server/internal/content"
+	"github.com/pcstyle/ssh-server/internal/projects"
+	"github.com/pcstyle/ssh-server/internal/store"
+)
+
+// Session carries per-connection details and shared server stores into the UI
+type Session struct {
+	// Fingerprint is the SHA256 fingerprint of the visitor's public key,
+	// empty for password logins
+	Fingerprint string
+
+	// Env is the environment the client sent (LANG, LC_ALL, ...)
+	Env []string
+
+	// Caps is what the visitor's terminal can render, see DetectCapabilities
+	Caps Capabilities
+
+	// Terminal writes straight to the visitor's terminal, for escape
+	// sequences a view can't carry (OSC 52 copy). It must be the same
+	// locked writer the program renders to, so writes never split a frame.
+	Terminal io.Writer
+
+	// Drafts keeps unsent contact messages across disconnects
+	Drafts *store.Drafts
+
+	// Inbox holds conversations with key-authenticated visitors
+	Inbox *store.Inbox
+
+	// Scores is the arcade leaderboard, shared by every session. Left nil
+	// the games still run, nobody just gets on the board.
+	Scores *store.Leaderboard
+
+	// Arena matches visitors for multiplayer snake, shared by every
+	// session. Left nil the arcade has no multiplayer.
+	Arena *arena.Hub
+
+	// Done is closed when the visitor disconnects, so a seat they hold in
+	// an arena room is given back. Nil never closes.
+	Done <-chan struct{}
+
+	// Themes are user palettes loaded from config, offered after the built-ins
+	Themes []Palette
+
+	// Pages are the Markdown content pages, Secrets the hidden logbook entries
+	Pages   *content.Library
+	Secrets *content.Library
+
+	// FeedURL is the blog's RSS or Atom feed, empty hides the blog.
+	// Feeds caches the last good copy for when fetching fails.
+	FeedURL string
+	Feeds   *store.FeedCache
+
+	// Projects come from the projects data file, Monitor has their live
+	// status (shared by every session)
+	Projects []projects.Project
+	Monitor  *projects.Monitor
+
+	// Disabled names screen registrations to leave out, like "blog" or
+	// "arcade" (see RegisterScreen)
+	Disabled []string
+
+	// Clock and Seeds are where views get the time and their randomness.
+	// Left nil, NewModel uses the wall clock and unpredictable seeds.
+	Clock Clock
+	Seeds Seeds
+}
+
+// draftKey picks the key a session's contact draft is stored under
+func (s Session) draftKey(resumeCode string) string {
+	if s.Fingerprint != "" {
+		return "fp:" + s.Fingerprint
+	}
+	return "code:" + resumeCode
+}
@@ -0,0 +1,362 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pcstyle/ssh-server/internal/arena"
+)
+
+// lobbyRefresh co ile lista pokoi się odświeża, pokoje żyją na serwerze
+// i nikt nam nie powie że się zmieniły
+const lobbyRefresh = time.Second
+
+// lobbyFixed to wiersze lobby przed listą pokoi: quick match i nowy pokój
+const lobbyFixed = 2
+
+// arenaLobby to stan ekranu z pokojami. tick odróżnia aktualną pętlę
+// odświeżania od tych z poprzednich wejść, jak w projektach
+type arenaLobby struct {
+	rooms  []arena.RoomInfo
+	cursor int
+	tick   int
+}
+
+// arenaLobbyTickMsg odświeża listę pokoi
+type arenaLobbyTickMsg struct{ id int }
+
+// arenaMsg to nowy stan pokoju od huba dla konkretnego miejsca. closed =
+// kanał zamknięty, czyli wyszliśmy albo pokój padł
+type arenaMsg struct {
+	seat   *arena.Seat
+	snap   arena.Snapshot
+	closed bool
+}
+
+// listenArena czeka na następny stan pokoju. Każdy arenaMsg odpala kolejne
+// czekanie, więc leci to w kółko aż kanał się zamknie
+func listenArena(seat *arena.Seat) tea.Cmd {
+	return func() tea.Msg {
+		snap, ok := <-seat.Updates()
+		return arenaMsg{seat: seat, snap: snap, closed: !ok}
+	}
+}
+
+// openLobby pokazuje pokoje i puszcza odświeżanie
+func (m *ArcadeModel) openLobby() tea.Cmd {
+	m.state = arcadeStateLobby
+	m.statusLine = "arcade.status.arena_lobby"
+	return m.refreshLobby()
+}
+
+// refreshLobby bierze świeżą listę i odpala nową pętlę, stara wygasa
+func (m *ArcadeModel) refreshLobby() tea.Cmd {
+	m.lobby.rooms = m.arena.Rooms()
+	m.lobby.cursor = clamp(m.lobby.cursor, 0, lobbyFixed+len(m.lobby.rooms)-1)
+	m.lobby.tick++
+	id := m.lobby.tick
+	return tea.Tick(lobbyRefresh, func(time.Time) tea.Msg {
+		return arenaLobbyTickMsg{id: id}
+	})
+}
+
+func (m ArcadeModel) onLobbyTick(msg arenaLobbyTickMsg) (ArcadeModel, tea.Cmd) {
+	if m.state != arcadeStateLobby || msg.id != m.lobby.tick {
+		return m, nil
+	}
+	cmd := m.refreshLobby()
+	return m, cmd
+}
+
+// arenaName to inicjały z high score jak już jakieś wpisał, inaczej
+// pokój pokaże numer miejsca
+func (m ArcadeModel) arenaName() string {
+	if m.initials.letters[0] == 0 {
+		return ""
+	}
+	return string(m.initials.letters[:])
+}
+
+// joinArena wchodzi tam gdzie stoi kursor: quick match, nowy pokój albo
+// konkretny z listy (pełny = jako widz)
+func (m *ArcadeModel) joinArena() tea.Cmd {
+	var seat *arena.Seat
+	var err error
+	switch i := m.lobby.cursor; {
+	case i == 0:
+		seat, err = m.arena.QuickMatch(m.arenaName(), m.done)
+	case i == 1:
+		seat, err = m.arena.Open(m.arenaName(), m.done)
+	case i-lobbyFixed < len(m.lobby.rooms):
+		seat, err = m.arena.Join(m.lobby.rooms[i-lobbyFixed].ID, m.arenaName(), m.done)
+	default:
+		return nil
+	}
+	if err != nil {
+		cmd := m.refreshLobby()
+		m.statusLine = "arcade.status.arena_closed"
+		if errors.Is(err, arena.ErrNoRoom) {
+			m.statusLine = "arcade.status.arena_gone"
+		}
+		return cmd
+	}
+
+	m.seat = seat
+	m.room = arena.Snapshot{}
+	m.lobby.tick++ // lobby stoi, jak wrócimy to ruszy od nowa
+	m.state = arcadeStateArena
+	m.statusLine = "arcade.status.arena_joined"
+	if seat.Spectating() {
+		m.statusLine = "arcade.status.arena_watching"
+	}
+	return listenArena(seat)
+}
+
+// leaveArena oddaje miejsce i wraca do lobby
+func (m *ArcadeModel) leaveArena() tea.Cmd {
+	if m.seat != nil {
+		m.seat.Leave()
+		m.seat = nil
+	}
+	cmd := m.openLobby()
+	m.statusLine = "arcade.status.arena_left"
+	return cmd
+}
+
+func (m ArcadeModel) onArenaMsg(msg arenaMsg) (ArcadeModel, tea.Cmd) {
+	if msg.seat != m.seat || m.seat == nil {
+		return m, nil // stare miejsce, z pokoju już wyszliśmy
+	}
+	if msg.closed {
+		m.seat = nil
+		var cmd tea.Cmd
+		if m.state == arcadeStateArena {
+			cmd = m.openLobby()
+			m.statusLine = "arcade.status.arena_closed"
+		}
+		return m, cmd
+	}
+	m.room = msg.snap
+	return m, listenArena(m.seat)
+}
+
+func (m ArcadeModel) handleLobbyKey(msg tea.KeyMsg) (ArcadeModel, tea.Cmd) {
+	n := lobbyFixed + len(m.lobby.rooms)
+	switch {
+	case key.Matches(msg, arenaLobbyKeys.Up):
+		m.lobby.cursor = (m.lobby.cursor + n - 1) % n
+	case key.Matches(msg, arenaLobbyKeys.Down):
+		m.lobby.cursor = (m.lobby.cursor + 1) % n
+	case key.Matches(msg, arenaLobbyKeys.Join):
+		cmd := m.joinArena()
+		return m, cmd
+	case key.Matches(msg, arenaLobbyKeys.Back):
+		m.lobby.tick++
+		m.state = arcadeStateMenu
+		m.statusLine = "arcade.status.ready"
+	}
+	return m, nil
+}
+
+func (m ArcadeModel) handleArenaKey(msg tea.KeyMsg) (ArcadeModel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, arenaKeys.Leave):
+		cmd := m.leaveArena()
+		return m, cmd
+	case m.seat == nil:
+	case key.Matches(msg, arenaKeys.Up):
+		m.seat.Turn(arena.Up)
+	case key.Matches(msg, arenaKeys.Down):
+		m.seat.Turn(arena.Down)
+	case key.Matches(msg, arenaKeys.Left):
+		m.seat.Turn(arena.Left)
+	case key.Matches(msg, arenaKeys.Right):
+		m.seat.Turn(arena.Right)
+	}
+	return m, nil
+}
+
+// arenaKeyMap: widz nie steruje, ma tylko wyjście
+func (m ArcadeModel) arenaKeyMap() arenaKeyMap {
+	keys := arenaKeys
+	if m.seat != nil && m.seat.Spectating() {
+		keys.Up.SetEnabled(false)
+		keys.Down.SetEnabled(false)
+		keys.Left.SetEnabled(false)
+		keys.Right.SetEnabled(false)
+	}
+	return keys
+}
+
+// playerName to imię gracza albo numer miejsca jak nie podał
+func (m ArcadeModel) playerName(s arena.Snake) string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return m.tr.T("arena.player", s.Player+1)
+}
+
+func (m ArcadeModel) renderLobby() string {
+	rows := []string{
+		m.lobbyRow(0, m.tr.T("arena.quick"), m.tr.T("arena.quick_desc")),
+		m.lobbyRow(1, m.tr.T("arena.new"), m.tr.T("arena.new_desc")),
+	}
+	for i, room := range m.lobby.rooms {
+		info := []string{m.tr.T(arenaPhases[room.Phase])}
+		if room.Spectators > 0 {
+			info = append(info, m.tr.T("arena.spectators", room.Spectators))
+		}
+		if room.Full() {
+			info = append(info, m.tr.T("arena.full"))
+		}
+		title := m.tr.T("arena.room", room.ID, room.Players, room.Seats, strings.Join(info, " "+m.theme.Glyphs.Bullet+" "))
+		rows = append(rows, m.lobbyRow(lobbyFixed+i, title, ""))
+	}
+	if len(m.lobby.rooms) == 0 {
+		rows = append(rows, m.theme.Help.UnsetMarginTop().Render("  "+m.tr.T("arena.no_rooms")))
+	}
+
+	lines := []string{
+		m.theme.Title.Render(m.tr.T("arena.lobby_title")),
+		strings.Join(rows, "\n"),
+		keyHelp(m.theme, m.tr, m.width, m.KeyMap()),
+	}
+	if m.statusLine != "" {
+		lines = append(lines, m.theme.RenderHelp(m.width, m.tr.T(m.statusLine)))
+	}
+	return m.theme.BoxFor(m.width).Render(strings.Join(lines, "\n\n"))
+}
+
+// lobbyRow to jeden wiersz lobby, jak wpis w menu arcade
+func (m ArcadeModel) lobbyRow(i int, title, desc string) string {
+	cursor := "  "
+	style := m.theme.NavItem
+	if i == m.lobby.cursor {
+		cursor = m.theme.NavArrow.Render(m.theme.Glyphs.Arrow + " ")
+		style = m.theme.NavItemSelected
+	}
+	line := cursor + style.Render(title)
+	if desc != "" {
+		line += " - " + m.theme.Help.UnsetMarginTop().Render(desc)
+	}
+	return zone(fmt.Sprintf("arena.%d", i), line)
+}
+
+// arenaPhases to nazwy faz pokoju w lobby
+var arenaPhases = map[arena.Phase]string{
+	arena.PhaseWaiting:   "arena.phase.waiting",
+	arena.PhaseCountdown: "arena.phase.countdown",
+	arena.PhasePlaying:   "arena.phase.playing",
+	arena.PhaseOver:      "arena.phase.over",
+}
+
+func (m ArcadeModel) renderArena() string {
+	room := m.room
+	if room.Width == 0 {
+		return m.theme.BoxFor(m.width).Render(m.tr.T("arena.joining"))
+	}
+
+	you := -1
+	if m.seat != nil {
+		you = m.seat.Player()
+	}
+	var legend []string
+	for _, s := range room.Snakes {
+		line := playerStyle(m.theme, s.Player).Render(m.theme.Glyphs.SnakeHead) + " " + m.tr.T("arena.legend", m.playerName(s), s.Score, s.Wins)
+		if !s.Alive && room.Phase != arena.PhaseWaiting {
+			line += "  " + m.theme.Help.UnsetMarginTop().Render(m.tr.T("arena.out"))
+		}
+		if s.Player == you {
+			line += "  " + m.theme.NavItemSelected.UnsetPadding().Render(m.tr.T("arena.you"))
+		}
+		legend = append(legend, line)
+	}
+
+	lines := []string{
+		m.theme.Title.Render(m.tr.T("arena.title", room.Room)),
+		drawArena(room, m.theme),
+		strings.Join(legend, "\n"),
+		keyHelp(m.theme, m.tr, m.width, m.KeyMap()),
+		m.arenaBanner(),
+	}
+	if m.seat != nil && m.seat.Spectating() {
+		lines = append(lines, m.theme.Help.UnsetMarginTop().Render(m.tr.T("arena.spectating", room.Spectators)))
+	}
+	if m.statusLine != "" {
+		lines = append(lines, m.theme.RenderHelp(m.width, m.tr.T(m.statusLine)))
+	}
+	return m.theme.BoxFor(m.width).Render(strings.Join(lines, "\n\n"))
+}
+
+// arenaBanner to co się dzieje w rundzie: czekanie, odliczanie, kto wygrał
+func (m ArcadeModel) arenaBanner() string {
+	room := m.room
+	// sekundy w górę, żeby odliczanie nie pokazywało 0 zanim ruszy
+	seconds := int((room.Countdown + time.Second - 1) / time.Second)
+	switch room.Phase {
+	case arena.PhaseCountdown:
+		return m.theme.Success.Render(m.tr.T("arena.countdown", room.Round, seconds))
+	case arena.PhasePlaying:
+		return m.theme.Success.Render(m.tr.T("arena.go", room.Round))
+	case arena.PhaseOver:
+		for _, s := range room.Snakes {
+			if s.Player == room.Winner {
+				return m.theme.Success.Render(m.tr.T("arena.winner", m.playerName(s), room.Round, seconds))
+			}
+		}
+		return m.theme.Error.Render(m.tr.T("arena.draw", room.Round, seconds))
+	default:
+		return m.theme.Help.UnsetMarginTop().Render(m.tr.T("arena.waiting"))
+	}
+}
+
+// playerStyle to kolor miejsca, jak graczy więcej niż kolorów to w kółko
+func playerStyle(theme *Theme, player int) lipgloss.Style {
+	return theme.Players[player%len(theme.Players)]
+}
+
+// drawArena rysuje planszę pokoju, każdy snake w kolorze swojego miejsca.
+// Bez kolorów ciało to numer miejsca, inaczej wszyscy wyglądają tak samo
+func drawArena(room arena.Snapshot, theme *Theme) string {
+	glyphs := theme.Glyphs
+	cells := make(map[arena.Point]string)
+	for _, apple := range room.Apples {
+		cells[apple] = glyphs.Apple
+	}
+	for _, s := range room.Snakes {
+		style := playerStyle(theme, s.Player)
+		body := glyphs.SnakeBody
+		if theme.Caps.Color == TierMono {
+			body = strconv.Itoa(s.Player + 1)
+		}
+		for i := len(s.Body) - 1; i >= 0; i-- {
+			glyph := body
+			if i == 0 {
+				glyph = glyphs.SnakeHead
+			}
+			cells[s.Body[i]] = style.Render(glyph)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(glyphs.TopLeft + strings.Repeat(glyphs.Horizontal, room.Width) + glyphs.TopRight + "\n")
+	for y := range room.Height {
+		b.WriteString(glyphs.Vertical)
+		for x := range room.Width {
+			if cell, ok := cells[arena.Point{X: x, Y: y}]; ok {
+				b.WriteString(cell)
+			} else {
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString(glyphs.Vertical + "\n")
+	}
+	b.WriteString(glyphs.BottomLeft + strings.Repeat(glyphs.Horizontal, room.Width) + glyphs.BottomRight)
+	return b.String()
+}
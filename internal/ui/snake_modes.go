@@ -0,0 +1,199 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// snakeApplePoints za zwykłe jabłko, snakeBonusPoints za bonusowe
+	snakeApplePoints = 10
+	snakeBonusPoints = 50
+	// snakeBonusChance: bonus wyskakuje średnio co tyle jabłek,
+	// i wisi snakeBonusTicks ticków
+	snakeBonusChance = 4
+	snakeBonusTicks  = 30
+)
+
+// snakeDifficulty to tempo i ciasnota planszy
+type snakeDifficulty struct {
+	id    string
+	title string
+	// speed na start, minSpeed to dno, speedUp ile schodzi za jabłko
+	speed    time.Duration
+	minSpeed time.Duration
+	speedUp  time.Duration
+	// walls to ile kawałków przeszkód na pierwszym levelu, potem +1 co
+	// level; apples ile jabłek trzeba zjeść żeby level zaliczyć
+	walls  int
+	apples int
+}
+
+var snakeDifficulties = []snakeDifficulty{
+	{id: "easy", title: "snake.difficulty.easy", speed: 220 * time.Millisecond, minSpeed: 120 * time.Millisecond, speedUp: 6 * time.Millisecond, walls: 0, apples: 8},
+	{id: "normal", title: "snake.difficulty.normal", speed: 180 * time.Millisecond, minSpeed: 80 * time.Millisecond, speedUp: 8 * time.Millisecond, walls: 2, apples: 10},
+	{id: "hard", title: "snake.difficulty.hard", speed: 140 * time.Millisecond, minSpeed: 50 * time.Millisecond, speedUp: 10 * time.Millisecond, walls: 4, apples: 12},
+}
+
+// snakeMode to difficulty plus czy ściany zabijają czy się przez nie
+// przechodzi. Każdy tryb ma swoją tabelkę wyników
+type snakeMode struct {
+	skill int // indeks w snakeDifficulties
+	wrap  bool
+}
+
+// defaultSnakeMode to klasyk: normal, ściany zabijają
+var defaultSnakeMode = snakeMode{skill: 1}
+
+func (m snakeMode) difficulty() snakeDifficulty {
+	return snakeDifficulties[m.skill]
+}
+
+// id to nazwa gry na leaderboardzie. Klasyk zostaje "snake", żeby stare
+// wyniki dalej były w jego tabelce
+func (m snakeMode) id() string {
+	id := "snake"
+	if d := m.difficulty(); d.id != "normal" {
+		id += "-" + d.id
+	}
+	if m.wrap {
+		id += "-wrap"
+	}
+	return id
+}
+
+// title to np. "normal · wrap" do nagłówków
+func (m snakeMode) title(tr *Translator) string {
+	walls := "snake.walls.solid"
+	if m.wrap {
+		walls = "snake.walls.wrap"
+	}
+	return tr.T(m.difficulty().title) + " · " + tr.T(walls)
+}
+
+// snakeModes to wszystkie tryby, w kolejności do przełączania
+func snakeModes() []snakeMode {
+	var modes []snakeMode
+	for skill := range snakeDifficulties {
+		modes = append(modes, snakeMode{skill: skill}, snakeMode{skill: skill, wrap: true})
+	}
+	return modes
+}
+
+// modeIndex szuka trybu w snakeModes, nieznany to pierwszy
+func modeIndex(mode snakeMode) int {
+	for i, m := range snakeModes() {
+		if m == mode {
+			return i
+		}
+	}
+	return 0
+}
+
+// layout losuje przeszkody na level: proste kawałki ściany 3-6 pól, poziomo
+// albo pionowo. Róg ze startem zostaje pusty, żeby nie umierać na dzień
+// dobry. Losuje z g.random, więc seed wyznacza też przeszkody
+func (g *snakeGame) layout() map[snakePoint]bool {
+	obstacles := make(map[snakePoint]bool)
+	walls := g.mode.difficulty().walls + g.level - 1
+	for range walls {
+		// parę prób na kawałek, na małej planszy może się nie zmieścić
+		for range 20 {
+			length := 3 + g.random.Intn(4)
+			step := snakePoint{x: 1}
+			if g.random.Intn(2) == 0 {
+				step = snakePoint{y: 1}
+			}
+			start := snakePoint{x: g.random.Intn(g.width), y: g.random.Intn(g.height)}
+
+			var cells []snakePoint
+			for i := range length {
+				p := snakePoint{x: start.x + i*step.x, y: start.y + i*step.y}
+				if g.hitWall(p) || (p.x < 9 && p.y < 3) {
+					cells = nil
+					break
+				}
+				cells = append(cells, p)
+			}
+			if cells != nil {
+				for _, p := range cells {
+					obstacles[p] = true
+				}
+				break
+			}
+		}
+	}
+	return obstacles
+}
+
+// setupOptions to wiersze pickera: difficulty i ściany
+var setupOptions = []string{"snake.setup.difficulty", "snake.setup.walls"}
+
+// changeSetup kręci opcją pod kursorem o dir, w kółko
+func (m *ArcadeModel) changeSetup(dir int) {
+	switch m.setupCursor {
+	case 0:
+		n := len(snakeDifficulties)
+		m.snakeMode.skill = (m.snakeMode.skill + dir + n) % n
+	case 1:
+		m.snakeMode.wrap = !m.snakeMode.wrap
+	}
+}
+
+func (m ArcadeModel) handleSetupKey(msg tea.KeyMsg) (ArcadeModel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, snakeSetupKeys.Up):
+		m.setupCursor = (m.setupCursor + len(setupOptions) - 1) % len(setupOptions)
+	case key.Matches(msg, snakeSetupKeys.Down):
+		m.setupCursor = (m.setupCursor + 1) % len(setupOptions)
+	case key.Matches(msg, snakeSetupKeys.Prev):
+		m.changeSetup(-1)
+	case key.Matches(msg, snakeSetupKeys.Next):
+		m.changeSetup(1)
+	case key.Matches(msg, snakeSetupKeys.Start):
+		cmd := m.startSnake()
+		return m, cmd
+	case key.Matches(msg, snakeSetupKeys.Back):
+		m.state = arcadeStateMenu
+		m.statusLine = "arcade.status.ready"
+	}
+	return m, nil
+}
+
+func (m ArcadeModel) renderSnakeSetup() string {
+	walls := "snake.walls.solid"
+	if m.snakeMode.wrap {
+		walls = "snake.walls.wrap"
+	}
+	values := []string{m.tr.T(m.snakeMode.difficulty().title), m.tr.T(walls)}
+
+	var rows []string
+	for i, option := range setupOptions {
+		cursor := "  "
+		style := m.theme.NavItem
+		if i == m.setupCursor {
+			cursor = m.theme.NavArrow.Render(m.theme.Glyphs.Arrow + " ")
+			style = m.theme.NavItemSelected
+		}
+		row := fmt.Sprintf("%s%-12s %s", cursor, m.tr.T(option), style.Render("< "+values[i]+" >"))
+		rows = append(rows, zone(fmt.Sprintf("snake.setup.%d", i), row))
+	}
+
+	difficulty := m.snakeMode.difficulty()
+	lines := []string{
+		m.theme.Title.Render(m.tr.T("snake.setup.title")),
+		strings.Join(rows, "\n"),
+		m.theme.Help.UnsetMarginTop().Render(m.tr.T("snake.setup.goal", difficulty.apples)),
+		m.tr.T("snake.setup.best", m.bestScore(m.snakeMode)),
+		zone("snake.start", m.theme.ButtonActive.Render(m.tr.T("snake.setup.start"))),
+		keyHelp(m.theme, m.tr, m.width, m.KeyMap()),
+	}
+	if m.statusLine != "" {
+		lines = append(lines, m.theme.RenderHelp(m.width, m.tr.T(m.statusLine)))
+	}
+	return m.theme.BoxFor(m.width).Render(strings.Join(lines, "\n\n"))
+}
@@ -0,0 +1,423 @@
+package ui
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// snakeInput to jeden skręt: dir ustawiony kiedy gra miała tick ticków
+type snakeInput struct {
+	tick int
+	dir  snakePoint
+}
+
+// snakeReplay to cała gra w pigułce: tryb, plansza, seed i skręty. Reszta
+// (jabłka, przeszkody, bonusy) wychodzi z seeda, więc symulacja odtwarza
+// grę co do ticka. end to tick, na którym gra się skończyła
+type snakeReplay struct {
+	mode   snakeMode
+	width  int
+	height int
+	seed   int64
+	inputs []snakeInput
+	end    int
+}
+
+const (
+	// replayVersion to pierwszy bajt kodu, na wypadek zmiany formatu
+	replayVersion = 1
+	// maxReplayTicks to ile ticków serwer w ogóle zasymuluje, parę godzin gry
+	maxReplayTicks = 250_000
+	// maxReplayCode to limit na wklejony kod i na rozpakowane dane
+	maxReplayCode = 64 << 10
+)
+
+// errBadReplay to kod, który nie jest replayem albo jest popsuty
+var errBadReplay = errors.New("not a snake replay code")
+
+// snakeDirs to kierunki w kodzie, jeden bajt na skręt
+var snakeDirs = []snakePoint{{0, -1}, {0, 1}, {-1, 0}, {1, 0}}
+
+// replay pakuje zapis gry, zwykle już martwej
+func (g *snakeGame) replay() snakeReplay {
+	return snakeReplay{
+		mode:   g.mode,
+		width:  g.width,
+		height: g.height,
+		seed:   g.seed,
+		inputs: append([]snakeInput(nil), g.inputs...),
+		end:    g.ticks,
+	}
+}
+
+// encode robi z replayu kod do skopiowania: varinty, deflate, base64url
+func (r snakeReplay) encode() string {
+	var raw []byte
+	raw = append(raw, replayVersion)
+	raw = binary.AppendUvarint(raw, uint64(r.mode.skill))
+	raw = binary.AppendUvarint(raw, uint64(boolByte(r.mode.wrap)))
+	raw = binary.AppendUvarint(raw, uint64(r.width))
+	raw = binary.AppendUvarint(raw, uint64(r.height))
+	raw = binary.AppendVarint(raw, r.seed)
+	raw = binary.AppendUvarint(raw, uint64(r.end))
+	raw = binary.AppendUvarint(raw, uint64(len(r.inputs)))
+	last := 0
+	for _, in := range r.inputs {
+		raw = binary.AppendUvarint(raw, uint64(in.tick-last))
+		raw = append(raw, byte(dirIndex(in.dir)))
+		last = in.tick
+	}
+
+	var packed bytes.Buffer
+	w, _ := flate.NewWriter(&packed, flate.BestCompression)
+	_, _ = w.Write(raw)
+	_ = w.Close()
+	return base64.RawURLEncoding.EncodeToString(packed.Bytes())
+}
+
+// decodeReplay czyta kod z encode. Spacje i nowe linie (z zawijania albo
+// zaznaczania ręką) nie przeszkadzają. Wszystko jest sprawdzane, bo kod
+// przychodzi od kogokolwiek
+func decodeReplay(code string) (snakeReplay, error) {
+	code = strings.Join(strings.Fields(code), "")
+	if code == "" || len(code) > maxReplayCode {
+		return snakeReplay{}, errBadReplay
+	}
+	packed, err := base64.RawURLEncoding.DecodeString(code)
+	if err != nil {
+		return snakeReplay{}, errBadReplay
+	}
+	// śmieci doklejone za strumieniem deflate też robią kod popsutym
+	compressed := bytes.NewReader(packed)
+	raw, err := io.ReadAll(io.LimitReader(flate.NewReader(compressed), maxReplayCode))
+	if err != nil || compressed.Len() > 0 {
+		return snakeReplay{}, errBadReplay
+	}
+
+	in := bytes.NewReader(raw)
+	var fail bool
+	read := func(limit uint64) int {
+		v, err := binary.ReadUvarint(in)
+		if err != nil || v > limit {
+			fail = true
+		}
+		return int(v)
+	}
+
+	var r snakeReplay
+	if version, err := in.ReadByte(); err != nil || version != replayVersion {
+		return snakeReplay{}, errBadReplay
+	}
+	r.mode.skill = read(uint64(len(snakeDifficulties) - 1))
+	r.mode.wrap = read(1) == 1
+	r.width = read(200)
+	r.height = read(200)
+	if r.seed, err = binary.ReadVarint(in); err != nil {
+		return snakeReplay{}, errBadReplay
+	}
+	r.end = read(maxReplayTicks)
+	n := read(maxReplayCode)
+	if fail || r.width < 4 || r.height < 4 {
+		return snakeReplay{}, errBadReplay
+	}
+
+	tick := 0
+	for range n {
+		tick += read(maxReplayTicks)
+		dir := read(uint64(len(snakeDirs) - 1))
+		if fail || tick > r.end {
+			return snakeReplay{}, errBadReplay
+		}
+		r.inputs = append(r.inputs, snakeInput{tick: tick, dir: snakeDirs[dir]})
+	}
+	if fail || in.Len() > 0 {
+		return snakeReplay{}, errBadReplay
+	}
+	return r, nil
+}
+
+func boolByte(b bool) byte {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func dirIndex(dir snakePoint) int {
+	for i, d := range snakeDirs {
+		if d == dir {
+			return i
+		}
+	}
+	return 0
+}
+
+// simulate gra cały replay na raz, bez timerów, i zwraca skończoną grę.
+// Replay, który nie umiera dokładnie na end, jest podrobiony
+func (r snakeReplay) simulate() (*snakeGame, error) {
+	p := newSnakePlayback(r)
+	for !p.done() {
+		p.step()
+	}
+	if p.game.alive || p.game.ticks != r.end {
+		return nil, fmt.Errorf("%w: the game doesn't end on tick %d", errBadReplay, r.end)
+	}
+	return p.game, nil
+}
+
+// verifySnakeReplay to weryfikacja dla leaderboardu: replay musi być z
+// tego trybu, a symulacja daje prawdziwy wynik i liczbę ticków
+func verifySnakeReplay(code string, mode snakeMode) (points, ticks int, err error) {
+	r, err := decodeReplay(code)
+	if err != nil {
+		return 0, 0, err
+	}
+	if r.mode != mode {
+		return 0, 0, fmt.Errorf("%w: played in %s, not %s", errBadReplay, r.mode.id(), mode.id())
+	}
+	g, err := r.simulate()
+	if err != nil {
+		return 0, 0, err
+	}
+	return g.score, g.ticks, nil
+}
+
+// playbackSpeeds to prędkości odtwarzania w procentach
+var playbackSpeeds = []int{25, 50, 100, 200, 400}
+
+// normalSpeed to indeks 1x w playbackSpeeds
+const normalSpeed = 2
+
+// snakePlayback odtwarza replay tick po ticku, z własnymi timerami
+type snakePlayback struct {
+	replay snakeReplay
+	game   *snakeGame
+	next   int
+	speed  int
+	paused bool
+	gen    int
+}
+
+// replayTickMsg to tick odtwarzania, jak snakeTickMsg z generacją
+type replayTickMsg struct {
+	playback *snakePlayback
+	gen      int
+}
+
+func newSnakePlayback(r snakeReplay) *snakePlayback {
+	return &snakePlayback{
+		replay: r,
+		game:   newSnakeGame(r.width, r.height, r.seed, r.mode),
+		speed:  normalSpeed,
+	}
+}
+
+// step to jeden tick: najpierw skręty z tego ticka, potem ruch. Level
+// complete leci od razu dalej, w grze to czekało tylko na enter
+func (p *snakePlayback) step() {
+	g := p.game
+	for p.next < len(p.replay.inputs) && p.replay.inputs[p.next].tick <= g.ticks {
+		dir := p.replay.inputs[p.next].dir
+		g.queueDir(dir.x, dir.y)
+		p.next++
+	}
+	if !g.step() && g.alive && g.levelDone {
+		g.advance()
+	}
+}
+
+func (p *snakePlayback) done() bool {
+	return !p.game.alive || p.game.ticks >= p.replay.end
+}
+
+// restart odtwarza od początku, z tą samą prędkością
+func (p *snakePlayback) restart() tea.Cmd {
+	p.game = newSnakeGame(p.replay.width, p.replay.height, p.replay.seed, p.replay.mode)
+	p.next = 0
+	p.paused = false
+	return p.init()
+}
+
+// init puszcza następny tick, w tempie gry przeskalowanym prędkością
+func (p *snakePlayback) init() tea.Cmd {
+	if p.paused || p.done() {
+		return nil
+	}
+	p.gen++
+	gen := p.gen
+	interval := p.game.speed * 100 / time.Duration(playbackSpeeds[p.speed])
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return replayTickMsg{playback: p, gen: gen}
+	})
+}
+
+// changeSpeed przesuwa prędkość o dir, nowy tick leci już w nowym tempie
+func (p *snakePlayback) changeSpeed(dir int) tea.Cmd {
+	p.speed = clamp(p.speed+dir, 0, len(playbackSpeeds)-1)
+	return p.init()
+}
+
+func (p *snakePlayback) togglePause() tea.Cmd {
+	if p.done() {
+		return nil
+	}
+	p.paused = !p.paused
+	p.gen++
+	return p.init()
+}
+
+// speedLabel to np. "0.5x"
+func (p *snakePlayback) speedLabel() string {
+	return fmt.Sprintf("%gx", float64(playbackSpeeds[p.speed])/100)
+}
+
+// replay w arcade ------------------------------------------------------------
+
+// watch odpala odtwarzanie r, esc wraca do back
+func (m *ArcadeModel) watch(r snakeReplay, back arcadeState) tea.Cmd {
+	m.playback = newSnakePlayback(r)
+	m.replayBack = back
+	m.state = arcadeStateReplay
+	m.statusLine = "arcade.status.replay_rolling"
+	return m.playback.init()
+}
+
+// shareReplay kopiuje kod replayu martwego snake'a i pokazuje go pod planszą
+func (m *ArcadeModel) shareReplay() tea.Cmd {
+	code := m.snake.replay().encode()
+	m.shareCode = code
+	m.statusLine = "arcade.status.replay_copied"
+	return func() tea.Msg { return CopyMsg{Text: code} }
+}
+
+// openReplayLoad to ekran do wklejenia cudzego kodu
+func (m *ArcadeModel) openReplayLoad() tea.Cmd {
+	m.state = arcadeStateReplayLoad
+	m.statusLine = "arcade.status.replay_paste"
+	m.replayInput.SetValue("")
+	return m.replayInput.Focus()
+}
+
+func (m ArcadeModel) handleReplayKey(msg tea.KeyMsg) (ArcadeModel, tea.Cmd) {
+	p := m.playback
+	switch {
+	case key.Matches(msg, replayKeys.Slower):
+		cmd := p.changeSpeed(-1)
+		return m, cmd
+	case key.Matches(msg, replayKeys.Faster):
+		cmd := p.changeSpeed(1)
+		return m, cmd
+	case key.Matches(msg, replayKeys.Pause):
+		cmd := p.togglePause()
+		return m, cmd
+	case key.Matches(msg, replayKeys.Restart):
+		m.statusLine = "arcade.status.replay_rolling"
+		cmd := p.restart()
+		return m, cmd
+	case key.Matches(msg, replayKeys.Leave):
+		p.gen++ // ticki w locie do kosza
+		m.state = m.replayBack
+		m.statusLine = "arcade.status.replay_left"
+	}
+	return m, nil
+}
+
+func (m ArcadeModel) handleReplayLoadKey(msg tea.KeyMsg) (ArcadeModel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, replayLoadKeys.Cancel):
+		m.replayInput.Blur()
+		m.state = arcadeStateMenu
+		m.statusLine = "arcade.status.ready"
+		return m, nil
+	case key.Matches(msg, replayLoadKeys.Load):
+		r, err := decodeReplay(m.replayInput.Value())
+		if err != nil {
+			m.statusLine = "arcade.status.replay_bad"
+			return m, nil
+		}
+		m.replayInput.Blur()
+		cmd := m.watch(r, arcadeStateMenu)
+		return m, cmd
+	}
+	var cmd tea.Cmd
+	m.replayInput, cmd = m.replayInput.Update(msg)
+	return m, cmd
+}
+
+// onReplayTick to jeden krok odtwarzania, stare ticki odpadają
+func (m ArcadeModel) onReplayTick(msg replayTickMsg) (ArcadeModel, tea.Cmd) {
+	p := m.playback
+	if m.state != arcadeStateReplay || msg.playback != p || msg.gen != p.gen {
+		return m, nil
+	}
+	p.step()
+	if p.done() {
+		m.statusLine = "arcade.status.replay_over"
+	}
+	return m, p.init()
+}
+
+func (m ArcadeModel) renderReplay() string {
+	p := m.playback
+	g := p.game
+	hud := m.tr.T("replay.hud", g.score, g.level, g.ticks, p.replay.end, p.speedLabel())
+	lines := []string{
+		m.theme.Title.Render(m.tr.T("replay.title")) + "  " + m.theme.Help.UnsetMarginTop().Render(g.mode.title(m.tr)),
+		zone("replay.board", g.draw(m.theme.Glyphs)),
+		keyHelp(m.theme, m.tr, m.width, m.KeyMap(), hud),
+	}
+	switch {
+	case p.done():
+		lines = append(lines, m.theme.Success.Render(m.tr.T("replay.over")))
+	case p.paused:
+		lines = append(lines, m.theme.Success.Render(m.tr.T("replay.paused")))
+	}
+	if m.statusLine != "" {
+		lines = append(lines, m.theme.RenderHelp(m.width, m.tr.T(m.statusLine)))
+	}
+	return m.theme.BoxFor(m.width).Render(strings.Join(lines, "\n\n"))
+}
+
+func (m ArcadeModel) renderReplayLoad() string {
+	input := m.replayInput
+	if m.width > 0 {
+		input.Width = clamp(m.width-16, 10, 60)
+	}
+	lines := []string{
+		m.theme.Title.Render(m.tr.T("replay.load_title")),
+		m.tr.T("replay.prompt"),
+		m.theme.InputFocused.Render(input.View()),
+		keyHelp(m.theme, m.tr, m.width, m.KeyMap()),
+	}
+	if m.statusLine != "" {
+		lines = append(lines, m.theme.RenderHelp(m.width, m.tr.T(m.statusLine)))
+	}
+	return m.theme.BoxFor(m.width).Render(strings.Join(lines, "\n\n"))
+}
+
+// renderShareCode łamie kod na kawałki, żeby zmieścił się w ramce. Bez
+// ramki dookoła, żeby dało się go zaznaczyć ręką; decodeReplay i tak
+// wytnie nowe linie przy wklejaniu
+func (m ArcadeModel) renderShareCode() string {
+	width := 60
+	if m.width > 0 {
+		width = clamp(m.width-12, 16, 60)
+	}
+	var chunks []string
+	for code := m.shareCode; code != ""; {
+		n := min(width, len(code))
+		chunks = append(chunks, code[:n])
+		code = code[n:]
+	}
+	return m.tr.T("snake.share") + "\n" + strings.Join(chunks, "\n")
+}
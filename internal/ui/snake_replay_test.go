@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pcstyle/ssh-server/internal/store"
+)
+
+// playSnake plays a game the way a greedy bot would: chase apples until
+// it has some points, then spin on the spot until it bites itself
+func playSnake(t *testing.T, mode snakeMode, seed int64) *snakeGame {
+	t.Helper()
+	g := newSnakeGame(30, 14, seed, mode)
+	for g.alive && g.ticks < 5000 {
+		if g.score < 30 {
+			g.steerTowards(g.apple)
+		} else {
+			g.queueDir(-g.dir.y, g.dir.x)
+		}
+		if !g.step() && g.levelDone {
+			g.advance()
+		}
+	}
+	if g.alive {
+		t.Fatal("scripted game never ended")
+	}
+	return g
+}
+
+func TestReplayRoundTrip(t *testing.T) {
+	for _, mode := range snakeModes() {
+		g := playSnake(t, mode, 99)
+
+		r, err := decodeReplay(g.replay().encode())
+		if err != nil {
+			t.Fatalf("%s: %v", mode.id(), err)
+		}
+		replayed, err := r.simulate()
+		if err != nil {
+			t.Fatalf("%s: %v", mode.id(), err)
+		}
+		if replayed.score != g.score || replayed.ticks != g.ticks || replayed.level != g.level {
+			t.Errorf("%s: replay ends at %d points, tick %d, level %d; the game at %d, %d, %d",
+				mode.id(), replayed.score, replayed.ticks, replayed.level, g.score, g.ticks, g.level)
+		}
+	}
+}
+
+func TestBadReplayCodes(t *testing.T) {
+	code := playSnake(t, defaultSnakeMode, 99).replay().encode()
+	for name, bad := range map[string]string{
+		"empty":     "",
+		"garbage":   "hello, world",
+		"truncated": code[:len(code)/2],
+		"extended":  code + "AAAA",
+	} {
+		if _, err := decodeReplay(bad); !errors.Is(err, errBadReplay) {
+			t.Errorf("%s code decoded, err = %v", name, err)
+		}
+	}
+	if _, err := decodeReplay(code[:20] + "\n" + code[20:]); err != nil {
+		t.Errorf("wrapped code: %v", err)
+	}
+}
+
+func TestLeaderboardReplaysSubmissions(t *testing.T) {
+	scores, err := store.NewLeaderboard(filepath.Join(t.TempDir(), "scores.json"), ArcadeRules())
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := playSnake(t, defaultSnakeMode, 99)
+	if g.score == 0 {
+		t.Fatal("the scripted game should score something")
+	}
+	honest := store.Score{
+		Game:     defaultSnakeMode.id(),
+		Name:     "ACE",
+		Points:   g.score,
+		At:       time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+		Duration: time.Hour,
+		Ticks:    g.ticks,
+		Replay:   g.replay().encode(),
+	}
+
+	padded := honest
+	padded.Points += 10
+	noReplay := honest
+	noReplay.Replay = ""
+	otherMode := honest
+	otherMode.Game = snakeMode{skill: 0}.id()
+	for name, s := range map[string]store.Score{"padded": padded, "no replay": noReplay, "other mode": otherMode} {
+		if err := scores.Submit(s); !errors.Is(err, store.ErrImplausible) {
+			t.Errorf("%s score: Submit = %v, want ErrImplausible", name, err)
+		}
+	}
+
+	if err := scores.Submit(honest); err != nil {
+		t.Fatalf("honest score: %v", err)
+	}
+	if top := scores.Top(honest.Game, time.Time{}, 10); len(top) != 1 || top[0].Name != "ACE" {
+		t.Errorf("board = %+v", top)
+	}
+}
@@ -1,94 +1,403 @@
 package ui
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/lipgloss"
 )
 
-var (
-	// Brand colors (using basic ANSI colors for maximum compatibility)
-	ColorPrimary   = lipgloss.AdaptiveColor{Light: "51", Dark: "51"}     // Cyan
-	ColorSecondary = lipgloss.AdaptiveColor{Light: "198", Dark: "198"}   // Magenta
-	ColorSuccess   = lipgloss.AdaptiveColor{Light: "46", Dark: "46"}     // Bright Green
-	ColorError     = lipgloss.AdaptiveColor{Light: "196", Dark: "196"}   // Bright Red
-	ColorMuted     = lipgloss.AdaptiveColor{Light: "243", Dark: "243"}   // Gray
-	ColorWhite     = lipgloss.AdaptiveColor{Light: "255", Dark: "255"}   // White
+// Palette is the set of colors a theme is built from. Colors are ANSI
+// indexes ("51") or hex ("#00e5ff"); an empty color means "no color".
+type Palette struct {
+	Name      string `json:"name"`
+	Primary   string `json:"primary"`
+	Secondary string `json:"secondary"`
+	Success   string `json:"success"`
+	Error     string `json:"error"`
+	Muted     string `json:"muted"`
+	Text      string `json:"text"`
+	InputBg   string `json:"input_bg"`
+}
+
+// BuiltinPalettes are always available, the first one is the default
+var BuiltinPalettes = []Palette{
+	{
+		// Brand colors: cyan and magenta, matching pcstyle.dev
+		Name:      "neo-brutalist",
+		Primary:   "51",
+		Secondary: "198",
+		Success:   "46",
+		Error:     "196",
+		Muted:     "243",
+		Text:      "255",
+		InputBg:   "235",
+	},
+	{
+		Name:      "high-contrast",
+		Primary:   "226",
+		Secondary: "201",
+		Success:   "46",
+		Error:     "196",
+		Muted:     "252",
+		Text:      "231",
+		InputBg:   "16",
+	},
+	{
+		// For terminals with a light background
+		Name:      "light",
+		Primary:   "25",
+		Secondary: "161",
+		Success:   "28",
+		Error:     "160",
+		Muted:     "241",
+		Text:      "232",
+		InputBg:   "254",
+	},
+	{
+		Name:      "solarized",
+		Primary:   "#2aa198",
+		Secondary: "#d33682",
+		Success:   "#859900",
+		Error:     "#dc322f",
+		Muted:     "#586e75",
+		Text:      "#93a1a1",
+		InputBg:   "#073642",
+	},
+	{
+		// No colors at all, emphasis comes from bold/italic/reverse
+		Name: "monochrome",
+	},
+}
+
+// Theme holds the styles for one session. It is built from the session's
+// renderer so colors match what the visitor's terminal supports, and it is
+// shared by every view so switching palettes applies everywhere at once.
+type Theme struct {
+	Base            lipgloss.Style
+	Title           lipgloss.Style
+	NavItem         lipgloss.Style
+	NavItemSelected lipgloss.Style
+	NavArrow        lipgloss.Style
+	Label           lipgloss.Style
+	Input           lipgloss.Style
+	InputFocused    lipgloss.Style
+	Button          lipgloss.Style
+	ButtonActive    lipgloss.Style
+	ButtonHover     lipgloss.Style
+	Success         lipgloss.Style
+	Error           lipgloss.Style
+	FieldError      lipgloss.Style
+	Payload         lipgloss.Style
+	Help            lipgloss.Style
+	Box             lipgloss.Style
+
+	// Markdown pages
+	Link       lipgloss.Style
+	Strong     lipgloss.Style
+	Emphasis   lipgloss.Style
+	InlineCode lipgloss.Style
+	CodeBlock  lipgloss.Style
+	Quote      lipgloss.Style
+
+	// Key help under every view and in the ? overlay
+	Keys help.Styles
+
+	// Players color the snakes in a multiplayer room, one per seat
+	Players []lipgloss.Style
+
+	// Caps and Glyphs follow the visitor's terminal, not the palette
+	Caps   Capabilities
+	Glyphs Glyphs
+
+	renderer *lipgloss.Renderer
+	palettes []Palette
+	index    int
+}
+
+// NewTheme builds a theme from the first palette; the rest can be cycled to.
+// caps decides whether colors and Unicode glyphs are used at all.
+func NewTheme(renderer *lipgloss.Renderer, palettes []Palette, caps Capabilities) *Theme {
+	if renderer == nil {
+		renderer = lipgloss.DefaultRenderer()
+	}
+	if len(palettes) == 0 {
+		palettes = BuiltinPalettes
+	}
+
+	t := &Theme{renderer: renderer, palettes: palettes, Caps: caps, Glyphs: unicodeGlyphs}
+	if caps.ASCII {
+		t.Glyphs = asciiGlyphs
+	}
+	t.apply()
+	return t
+}
+
+// Name returns the active palette's name
+func (t *Theme) Name() string {
+	return t.palettes[t.index].Name
+}
+
+// Names lists the palettes in the order Cycle goes through them
+func (t *Theme) Names() []string {
+	names := make([]string, len(t.palettes))
+	for i, p := range t.palettes {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// Use switches to the palette called name, reporting whether there is one
+func (t *Theme) Use(name string) bool {
+	for i, p := range t.palettes {
+		if p.Name == name {
+			t.index = i
+			t.apply()
+			return true
+		}
+	}
+	return false
+}
+
+// Cycle switches to the next palette
+func (t *Theme) Cycle() {
+	t.index = (t.index + 1) % len(t.palettes)
+	t.apply()
+}
+
+// apply rebuilds every style from the active palette
+func (t *Theme) apply() {
+	p := t.palettes[t.index]
+	r := t.renderer
+
+	// Mono terminals get the monochrome look whatever palette is picked
+	if t.Caps.Color == TierMono {
+		p = Palette{Name: p.Name}
+	}
+
+	border, roundedBorder := lipgloss.NormalBorder(), lipgloss.RoundedBorder()
+	if t.Caps.ASCII {
+		border, roundedBorder = lipgloss.ASCIIBorder(), lipgloss.ASCIIBorder()
+	}
+
+	primary := paletteColor(p.Primary)
+	secondary := paletteColor(p.Secondary)
+	success := paletteColor(p.Success)
+	errColor := paletteColor(p.Error)
+	muted := paletteColor(p.Muted)
+	text := paletteColor(p.Text)
+	inputBg := paletteColor(p.InputBg)
 
 	// Base styles
-	BaseStyle = lipgloss.NewStyle().
-			Padding(1, 2)
+	t.Base = r.NewStyle().
+		Padding(1, 2)
 
 	// Title/banner style
-	TitleStyle = lipgloss.NewStyle().
-			Foreground(ColorPrimary).
-			Bold(true).
-			Align(lipgloss.Center)
+	t.Title = r.NewStyle().
+		Foreground(primary).
+		Bold(true).
+		Align(lipgloss.Center)
 
 	// Navigation styles
-	NavItemStyle = lipgloss.NewStyle().
-			Foreground(ColorWhite).
-			Padding(0, 2)
+	t.NavItem = r.NewStyle().
+		Foreground(text).
+		Padding(0, 2)
 
-	NavItemSelectedStyle = lipgloss.NewStyle().
-				Foreground(ColorPrimary).
-				Bold(true).
-				Padding(0, 2)
+	t.NavItemSelected = r.NewStyle().
+		Foreground(primary).
+		Bold(true).
+		Padding(0, 2)
 
-	NavArrowStyle = lipgloss.NewStyle().
-			Foreground(ColorSecondary).
-			Bold(true)
+	t.NavArrow = r.NewStyle().
+		Foreground(secondary).
+		Bold(true)
 
 	// Form styles
-	LabelStyle = lipgloss.NewStyle().
-			Foreground(ColorPrimary).
-			Bold(true).
-			MarginRight(1)
-
-	InputStyle = lipgloss.NewStyle().
-			Foreground(ColorWhite).
-			Background(lipgloss.AdaptiveColor{Light: "235", Dark: "235"}).
-			Padding(0, 1)
-
-	InputFocusedStyle = lipgloss.NewStyle().
-				Foreground(ColorPrimary).
-				Background(lipgloss.AdaptiveColor{Light: "235", Dark: "235"}).
-				Padding(0, 1).
-				BorderStyle(lipgloss.NormalBorder()).
-				BorderForeground(ColorPrimary)
+	t.Label = r.NewStyle().
+		Foreground(primary).
+		Bold(true).
+		MarginRight(1)
+
+	t.Input = r.NewStyle().
+		Foreground(text).
+		Background(inputBg).
+		Padding(0, 1)
+
+	t.InputFocused = r.NewStyle().
+		Foreground(primary).
+		Background(inputBg).
+		Padding(0, 1).
+		BorderStyle(border).
+		BorderForeground(primary)
 
 	// Button styles
-	ButtonStyle = lipgloss.NewStyle().
-			Foreground(ColorWhite).
-			Background(ColorSecondary).
-			Padding(0, 3).
-			Bold(true)
-
-	ButtonActiveStyle = lipgloss.NewStyle().
-				Foreground(ColorWhite).
-				Background(ColorPrimary).
-				Padding(0, 3).
-				Bold(true)
+	t.Button = r.NewStyle().
+		Foreground(text).
+		Background(secondary).
+		Padding(0, 3).
+		Bold(true)
+
+	t.ButtonActive = r.NewStyle().
+		Foreground(text).
+		Background(primary).
+		Padding(0, 3).
+		Bold(true)
+
+	// A button under the mouse pointer
+	t.ButtonHover = t.Button.Underline(true)
 
 	// Message styles
-	SuccessStyle = lipgloss.NewStyle().
-			Foreground(ColorSuccess).
-			Bold(true).
-			Padding(1, 2)
+	t.Success = r.NewStyle().
+		Foreground(success).
+		Bold(true).
+		Padding(1, 2)
+
+	t.Error = r.NewStyle().
+		Foreground(errColor).
+		Bold(true).
+		Padding(1, 2)
+
+	// Inline form validation error
+	t.FieldError = r.NewStyle().
+		Foreground(errColor).
+		PaddingLeft(1)
 
-	ErrorStyle = lipgloss.NewStyle().
-			Foreground(ColorError).
-			Bold(true).
-			Padding(1, 2)
+	// Raw payload preview on the contact confirm screen
+	t.Payload = r.NewStyle().
+		Foreground(text).
+		BorderStyle(border).
+		BorderForeground(muted).
+		Padding(0, 1)
 
 	// Help text style
-	HelpStyle = lipgloss.NewStyle().
-			Foreground(ColorMuted).
-			Italic(true).
-			MarginTop(1)
+	t.Help = r.NewStyle().
+		Foreground(muted).
+		Italic(true).
+		MarginTop(1)
 
 	// Box/container style
-	BoxStyle = lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(ColorPrimary).
-			Padding(1, 2).
-			MarginTop(1)
-)
+	t.Box = r.NewStyle().
+		BorderStyle(roundedBorder).
+		BorderForeground(primary).
+		Padding(1, 2).
+		MarginTop(1)
+
+	// Markdown styles; inline ones have no padding so they can sit mid-line
+	t.Link = r.NewStyle().
+		Foreground(primary).
+		Underline(true)
+
+	t.Strong = r.NewStyle().
+		Foreground(text).
+		Bold(true)
+
+	t.Emphasis = r.NewStyle().
+		Foreground(text).
+		Italic(true)
+
+	t.InlineCode = r.NewStyle().
+		Foreground(secondary)
+
+	t.CodeBlock = r.NewStyle().
+		Foreground(success).
+		BorderStyle(border).
+		BorderForeground(muted).
+		Padding(0, 1)
+
+	t.Quote = r.NewStyle().
+		Foreground(muted).
+		Italic(true)
+
+	// Snakes in a multiplayer room. Mono terminals can't tell these
+	// apart, so the room draws seat numbers there too.
+	t.Players = []lipgloss.Style{
+		r.NewStyle().Foreground(primary).Bold(true),
+		r.NewStyle().Foreground(secondary).Bold(true),
+		r.NewStyle().Foreground(success).Bold(true),
+		r.NewStyle().Foreground(errColor).Bold(true),
+	}
+
+	// Keys stand out a little from what they do
+	keyStyle := r.NewStyle().Foreground(secondary)
+	keyDesc := r.NewStyle().Foreground(muted).Italic(true)
+	keySep := r.NewStyle().Foreground(muted)
+	t.Keys = help.Styles{
+		ShortKey:       keyStyle,
+		ShortDesc:      keyDesc,
+		ShortSeparator: keySep,
+		Ellipsis:       keySep,
+		FullKey:        keyStyle,
+		FullDesc:       keyDesc,
+		FullSeparator:  keySep,
+	}
+
+	// Without colors, focus has to show some other way
+	if p.Primary == "" {
+		t.ButtonActive = t.ButtonActive.Reverse(true)
+		t.NavItemSelected = t.NavItemSelected.Underline(true)
+	}
+}
+
+// Fold swaps leftover Unicode symbols in a rendered view for ASCII ones
+// when the terminal can't show them
+func (t *Theme) Fold(view string) string {
+	if !t.Caps.ASCII {
+		return view
+	}
+	return asciiFold.Replace(view)
+}
+
+// Hyperlink wraps text in an OSC 8 link to url, so terminals that know the
+// sequence make it clickable. The rest get the plain text.
+func (t *Theme) Hyperlink(url, text string) string {
+	if !t.Caps.Hyperlinks || url == "" {
+		return text
+	}
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// paletteColor turns a palette entry into a lipgloss color
+func paletteColor(c string) lipgloss.TerminalColor {
+	if c == "" {
+		return lipgloss.NoColor{}
+	}
+	return lipgloss.Color(c)
+}
+
+// LoadPalettes reads user themes from *.json files in dir. A missing
+// directory just means there are no user themes.
+func LoadPalettes(dir string) ([]Palette, error) {
+	if dir == "" {
+		return nil, nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	var palettes []Palette
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read theme %s: %w", path, err)
+		}
+
+		var p Palette
+		if err := json.Unmarshal(data, &p); err != nil {
+			return nil, fmt.Errorf("failed to parse theme %s: %w", path, err)
+		}
+		if p.Name == "" {
+			p.Name = strings.TrimSuffix(filepath.Base(path), ".json")
+		}
+		palettes = append(palettes, p)
+	}
+
+	return palettes, nil
+}
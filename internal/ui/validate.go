@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pcstyle/ssh-server/internal/api"
+)
+
+// Field limits mirror the Zod schema of /api/contact (see API_CONTACT_ENDPOINT.md)
+const (
+	maxMessageLen  = 2000
+	maxNameLen     = 100
+	maxEmailLen    = 100
+	maxDiscordLen  = 100
+	maxPhoneLen    = 50
+	maxFacebookLen = 200
+)
+
+// contactTag is as long as what api.TagMessage puts in front of a contact
+// message, which counts towards the API's limit
+var contactTag = api.TagMessage(api.ReferenceID(0), "")
+
+var (
+	// Same shape as Zod's email regex, minus the lookaheads Go doesn't support
+	emailPattern = regexp.MustCompile(`(?i)^[a-z0-9_'+\-.]*[a-z0-9_+\-]@([a-z0-9][a-z0-9\-]*\.)+[a-z]{2,}$`)
+
+	// E.164-ish: optional +, no leading zero, 7-15 digits once separators are stripped
+	phonePattern = regexp.MustCompile(`^\+?[1-9][0-9]{6,14}$`)
+
+	// Discord usernames: 2-32 chars of a-z, 0-9, _ and . (legacy Name#1234 tags are still accepted)
+	discordPattern       = regexp.MustCompile(`^[a-z0-9_.]{2,32}$`)
+	discordLegacyPattern = regexp.MustCompile(`^[^@#:]{2,32}#[0-9]{4}$`)
+
+	facebookHosts = []string{"facebook.com", "fb.com", "fb.me"}
+)
+
+// validationError carries a catalog key so the message can be localized
+type validationError string
+
+// Error returns the English message, for logs and anything not localized
+func (e validationError) Error() string {
+	return NewTranslator(LocaleEN).T(string(e))
+}
+
+// localizeError renders err in the translator's locale when it's ours
+func localizeError(tr *Translator, err error) string {
+	var vErr validationError
+	if errors.As(err, &vErr) {
+		return tr.T(string(vErr))
+	}
+	return err.Error()
+}
+
+// validateContactField checks a single form value, returning nil when it's fine
+func validateContactField(field int, value string) error {
+	value = strings.TrimSpace(value)
+
+	switch field {
+	case fieldMessage:
+		return validateMessage(contactTag, value)
+	case fieldName:
+		if utf8.RuneCountInString(value) > maxNameLen {
+			return validationError("validate.name.long")
+		}
+	case fieldEmail:
+		return validateEmail(value)
+	case fieldDiscord:
+		return validateDiscord(value)
+	case fieldPhone:
+		return validatePhone(value)
+	case fieldFacebook:
+		return validateFacebook(value)
+	}
+
+	return nil
+}
+
+// validateMessage checks a message that is sent with tag in front of it
+func validateMessage(tag, value string) error {
+	if value == "" {
+		return validationError("validate.message.required")
+	}
+	if utf8.RuneCountInString(tag+value) > maxMessageLen {
+		return validationError("validate.message.long")
+	}
+	return nil
+}
+
+func validateEmail(value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(value) > maxEmailLen {
+		return validationError("validate.email.long")
+	}
+	if strings.HasPrefix(value, ".") || strings.Contains(value, "..") || !emailPattern.MatchString(value) {
+		return validationError("validate.email.invalid")
+	}
+	return nil
+}
+
+func validatePhone(value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(value) > maxPhoneLen {
+		return validationError("validate.phone.long")
+	}
+	if !phonePattern.MatchString(normalizePhone(value)) {
+		return validationError("validate.phone.invalid")
+	}
+	return nil
+}
+
+func validateDiscord(value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(value) > maxDiscordLen {
+		return validationError("validate.discord.long")
+	}
+
+	name := strings.TrimPrefix(value, "@")
+	if discordLegacyPattern.MatchString(name) {
+		return nil
+	}
+	if !discordPattern.MatchString(name) {
+		return validationError("validate.discord.invalid")
+	}
+	if strings.Contains(name, "..") {
+		return validationError("validate.discord.periods")
+	}
+	return nil
+}
+
+func validateFacebook(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	normalized := normalizeFacebook(value)
+	if len(normalized) > maxFacebookLen {
+		return validationError("validate.facebook.long")
+	}
+
+	u, err := url.Parse(normalized)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return validationError("validate.facebook.url")
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	for _, allowed := range facebookHosts {
+		if host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return nil
+		}
+	}
+	return validationError("validate.facebook.host")
+}
+
+// normalizePhone drops the separators people like to type between digits
+func normalizePhone(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(value))
+}
+
+// normalizeFacebook adds a scheme so "facebook.com/me" passes the API's URL check
+func normalizeFacebook(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.Contains(value, "://") {
+		return value
+	}
+	return "https://" + value
+}
@@ -0,0 +1,212 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Views mark the parts of their output that react to the mouse with zone.
+// The marks are escape sequences without width, so lipgloss lays marked
+// text out like any other. The app's View strips them from the final frame
+// and remembers where each zone ended up, which is how a click is matched
+// to what was drawn under it.
+
+// zoneNumbers gives every zone name a number for its marks. The names are
+// a small fixed set ("home.2", "link.4"), shared by every session.
+var zoneNumbers = struct {
+	sync.Mutex
+	byName map[string]int
+	names  []string
+}{byName: make(map[string]int)}
+
+func zoneNumber(name string) int {
+	zoneNumbers.Lock()
+	defer zoneNumbers.Unlock()
+	n, ok := zoneNumbers.byName[name]
+	if !ok {
+		n = len(zoneNumbers.names)
+		zoneNumbers.byName[name] = n
+		zoneNumbers.names = append(zoneNumbers.names, name)
+	}
+	return n
+}
+
+func zoneName(n int) string {
+	zoneNumbers.Lock()
+	defer zoneNumbers.Unlock()
+	if n < 0 || n >= len(zoneNumbers.names) {
+		return ""
+	}
+	return zoneNumbers.names[n]
+}
+
+// zone marks s as the zone called name. Zones can nest, the innermost one
+// under the pointer wins.
+func zone(name, s string) string {
+	n := strconv.Itoa(zoneNumber(name))
+	return "\x1b[" + n + "z" + s + "\x1b[" + n + ";0z"
+}
+
+// zoneIndex reads the number after prefix in a zone name, like 2 from
+// "home.2"
+func zoneIndex(name, prefix string) (int, bool) {
+	rest, ok := strings.CutPrefix(name, prefix)
+	if !ok {
+		return 0, false
+	}
+	i, err := strconv.Atoi(rest)
+	return i, err == nil && i >= 0
+}
+
+// zoneRect is where a zone was drawn. A zone whose end comes before its
+// start column spans lines like wrapped text: from the start to the end of
+// its first line, whole lines in between and up to the end on its last.
+// Anything else is a block, a rectangle from corner to corner.
+type zoneRect struct {
+	x1, y1 int
+	x2, y2 int // exclusive
+}
+
+func (r zoneRect) contains(x, y int) bool {
+	if y < r.y1 || y > r.y2 {
+		return false
+	}
+	if r.x1 < r.x2 || r.y1 == r.y2 {
+		return x >= r.x1 && x < r.x2
+	}
+	switch y {
+	case r.y1:
+		return x >= r.x1
+	case r.y2:
+		return x < r.x2
+	}
+	return true
+}
+
+func (r zoneRect) area() int {
+	return max(r.x2-r.x1, 1) * (r.y2 - r.y1 + 1)
+}
+
+// zoneMap is a session's zones as of the last frame. Like the Translator
+// it's shared by pointer: View records, Update looks up.
+type zoneMap struct {
+	mu    sync.Mutex
+	rects map[string]zoneRect
+}
+
+func newZoneMap() *zoneMap {
+	return &zoneMap{rects: make(map[string]zoneRect)}
+}
+
+// scan records where every zone in view is and returns view without the
+// marks. Zones cut off by scrolling are left out.
+func (z *zoneMap) scan(view string) string {
+	rects := make(map[string]zoneRect)
+	starts := make(map[int][2]int)
+
+	lines := strings.Split(view, "\n")
+	for y, line := range lines {
+		if !strings.Contains(line, "\x1b[") {
+			continue
+		}
+		var b strings.Builder
+		col := 0
+		for {
+			at, n, end, size := nextZoneMark(line)
+			if at < 0 {
+				b.WriteString(line)
+				break
+			}
+			b.WriteString(line[:at])
+			col += lipgloss.Width(line[:at])
+			line = line[at+size:]
+
+			if !end {
+				starts[n] = [2]int{col, y}
+			} else if start, ok := starts[n]; ok {
+				rects[zoneName(n)] = zoneRect{x1: start[0], y1: start[1], x2: col, y2: y}
+				delete(starts, n)
+			}
+		}
+		lines[y] = b.String()
+	}
+
+	z.mu.Lock()
+	z.rects = rects
+	z.mu.Unlock()
+	return strings.Join(lines, "\n")
+}
+
+// nextZoneMark finds the first mark in s: where it is, its zone number,
+// whether it ends the zone and how long it is. at is -1 without one.
+func nextZoneMark(s string) (at, n int, end bool, size int) {
+	from := 0
+	for {
+		i := strings.Index(s[from:], "\x1b[")
+		if i < 0 {
+			return -1, 0, false, 0
+		}
+		i += from
+		j := i + 2
+		for j < len(s) && (s[j] >= '0' && s[j] <= '9' || s[j] == ';') {
+			j++
+		}
+		if j < len(s) && s[j] == 'z' && j > i+2 {
+			params := s[i+2 : j]
+			num, rest, _ := strings.Cut(params, ";")
+			if v, err := strconv.Atoi(num); err == nil {
+				return i, v, rest != "", j + 1 - i
+			}
+		}
+		from = i + 2
+	}
+}
+
+// at names the innermost zone at x, y and where in it that is, "" when
+// there's none
+func (z *zoneMap) at(x, y int) (string, int, int) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	best, bestRect := "", zoneRect{}
+	for name, r := range z.rects {
+		if r.contains(x, y) && (best == "" || r.area() < bestRect.area()) {
+			best, bestRect = name, r
+		}
+	}
+	if best == "" {
+		return "", 0, 0
+	}
+	return best, x - bestRect.x1, y - bestRect.y1
+}
+
+// zoneMsg is a click or pointer move, matched to the zone under it. zone
+// is "" over nothing; x and y are relative to the zone's top left corner.
+// Wheel events reach views as plain tea.MouseMsg, so viewports scroll.
+type zoneMsg struct {
+	tea.MouseMsg
+	zone string
+	x, y int
+}
+
+// clicked reports a left button press
+func (m zoneMsg) clicked() bool {
+	return m.Action == tea.MouseActionPress && m.Button == tea.MouseButtonLeft
+}
+
+// wheel reports whether msg is the scroll wheel, and which way: -1 up, 1 down
+func wheel(msg tea.MouseMsg) (int, bool) {
+	if msg.Action != tea.MouseActionPress {
+		return 0, false
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return -1, true
+	case tea.MouseButtonWheelDown:
+		return 1, true
+	}
+	return 0, false
+}